@@ -0,0 +1,65 @@
+package ulid
+
+// EncodedBase32Len returns the length of the Crockford Base32 encoding of n
+// source bytes.
+func EncodedBase32Len(n int) int {
+	return (n*8 + 4) / 5
+}
+
+// DecodedBase32Len returns the number of bytes encoded by n Crockford Base32
+// characters.
+func DecodedBase32Len(n int) int {
+	return n * 5 / 8
+}
+
+// EncodeBase32 writes the lowercase Crockford Base32 encoding of src to dst,
+// most significant bit first, padding the final character with zero bits.
+// It writes EncodedBase32Len(len(src)) bytes and panics if dst is too short.
+// Encoding the 16-byte form of a ULID yields its canonical string.
+func EncodeBase32(dst, src []byte) {
+	_ = dst[:EncodedBase32Len(len(src))] // bounds check hint
+
+	var acc uint64
+	var n uint
+	j := 0
+	for _, b := range src {
+		acc = acc<<8 | uint64(b)
+		n += 8
+		for n >= 5 {
+			n -= 5
+			dst[j] = encodeTable[(acc>>n)&0x1F]
+			j++
+		}
+	}
+	if n > 0 {
+		dst[j] = encodeTable[(acc<<(5-n))&0x1F]
+	}
+}
+
+// DecodeBase32 decodes the Crockford Base32 string s into dst and returns the
+// number of bytes written, which is DecodedBase32Len(len(s)). Decoding is case
+// insensitive and maps the Crockford aliases I, L, O and U to 1, 1, 0 and V.
+// Leftover padding bits in the final character are ignored. An invalid
+// character is reported as an *InvalidCharacterError. It panics if dst is too
+// short.
+func DecodeBase32(dst []byte, s string) (int, error) {
+	_ = dst[:DecodedBase32Len(len(s))] // bounds check hint
+
+	var acc uint64
+	var n uint
+	j := 0
+	for i := range len(s) {
+		v := decodeTable[s[i]]
+		if v == 0xFF {
+			return j, &InvalidCharacterError{Pos: i, Char: s[i]}
+		}
+		acc = acc<<5 | uint64(v)
+		n += 5
+		if n >= 8 {
+			n -= 8
+			dst[j] = byte(acc >> n)
+			j++
+		}
+	}
+	return j, nil
+}
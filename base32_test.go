@@ -0,0 +1,67 @@
+package ulid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func TestBase32MatchesULIDEncoding(t *testing.T) {
+	ulidStr, err := New()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	u, _ := Parse(ulidStr)
+	data := u.bytes()
+
+	dst := make([]byte, EncodedBase32Len(len(data)))
+	EncodeBase32(dst, data[:])
+	if string(dst) != ulidStr {
+		t.Errorf("EncodeBase32 mismatch: got %s, expected %s", dst, ulidStr)
+	}
+
+	decoded := make([]byte, DecodedBase32Len(len(ulidStr)))
+	n, err := DecodeBase32(decoded, ulidStr)
+	if err != nil {
+		t.Fatalf("Error decoding: %v", err)
+	}
+	if n != totalBytes || !bytes.Equal(decoded, data[:]) {
+		t.Errorf("DecodeBase32 mismatch: got %x, expected %x", decoded[:n], data)
+	}
+}
+
+func TestBase32RoundTrip(t *testing.T) {
+	for size := range 40 {
+		src := make([]byte, size)
+		rand.Read(src)
+
+		encoded := make([]byte, EncodedBase32Len(size))
+		EncodeBase32(encoded, src)
+
+		decoded := make([]byte, DecodedBase32Len(len(encoded)))
+		n, err := DecodeBase32(decoded, string(bytes.ToUpper(encoded)))
+		if err != nil {
+			t.Fatalf("Error decoding %d bytes: %v", size, err)
+		}
+		if !bytes.Equal(decoded[:n], src) {
+			t.Fatalf("Round trip mismatch for %d bytes: got %x, expected %x", size, decoded[:n], src)
+		}
+	}
+
+	_, err := DecodeBase32(make([]byte, 8), "invalid!")
+	var charErr *InvalidCharacterError
+	if !errors.As(err, &charErr) || charErr.Pos != 7 || charErr.Char != '!' {
+		t.Errorf("Expected InvalidCharacterError at position 7, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("Expected error to match ErrInvalidCharacter, got %v", err)
+	}
+
+	aliased, canonical := make([]byte, 5), make([]byte, 5)
+	DecodeBase32(aliased, "ilouu000")
+	DecodeBase32(canonical, "110vv000")
+	if !bytes.Equal(aliased, canonical) {
+		t.Errorf("Expected aliases to decode as canonical characters, got %x and %x", aliased, canonical)
+	}
+}
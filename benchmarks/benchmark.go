@@ -1,15 +1,36 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"runtime"
+	"sort"
 	"time"
 
 	cloudresty "github.com/cloudresty/ulid"
 )
 
+var (
+	jsonFlag      = flag.String("json", "", "Write machine-readable results to this file")
+	compareFlag   = flag.String("compare", "", "Compare against a previous JSON results file")
+	thresholdFlag = flag.Float64("threshold", 10, "Fail when a benchmark is slower than the baseline by more than this percentage")
+)
+
+// Results is the machine-readable form of a benchmark run.
+type Results struct {
+	Generated  time.Time          `json:"generated"`
+	GoVersion  string             `json:"go_version"`
+	Platform   string             `json:"platform"`
+	CPUs       int                `json:"cpus"`
+	Iterations int                `json:"iterations"`
+	NsPerOp    map[string]float64 `json:"ns_per_op"`
+}
+
 func main() {
+	flag.Parse()
+
 	fmt.Println("🚀 ULID Performance Benchmark")
 	fmt.Printf("Go %s on %s/%s (%d CPUs)\n\n", runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
 
@@ -27,8 +48,100 @@ func main() {
 	fmt.Printf("Average: %v per ULID\n", elapsed/time.Duration(iterations))
 	fmt.Printf("Rate: %.2f million ULIDs/second\n\n", float64(iterations)/elapsed.Seconds()/1_000_000)
 
+	// Benchmark the encode and parse paths
+	sample, _ := cloudresty.New()
+	parsed, _ := cloudresty.Parse(sample)
+
+	results := Results{
+		Generated:  time.Now().UTC(),
+		GoVersion:  runtime.Version(),
+		Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+		CPUs:       runtime.NumCPU(),
+		Iterations: iterations,
+		NsPerOp: map[string]float64{
+			"New":    float64(elapsed.Nanoseconds()) / float64(iterations),
+			"String": measure(iterations, func() { _ = parsed.String() }),
+			"Parse":  measure(iterations, func() { _, _ = cloudresty.Parse(sample) }),
+		},
+	}
+
 	// Generate RESULTS.md
 	generateResults(iterations, elapsed)
+
+	if *jsonFlag != "" {
+		if err := writeJSON(*jsonFlag, results); err != nil {
+			fmt.Printf("Error writing %s: %v\n", *jsonFlag, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ %s generated successfully!\n", *jsonFlag)
+	}
+
+	if *compareFlag != "" {
+		regressed, err := compare(*compareFlag, results, *thresholdFlag)
+		if err != nil {
+			fmt.Printf("Error comparing with %s: %v\n", *compareFlag, err)
+			os.Exit(1)
+		}
+		if regressed {
+			fmt.Printf("❌ Performance regression above %.1f%% detected\n", *thresholdFlag)
+			os.Exit(1)
+		}
+	}
+}
+
+// measure returns the average nanoseconds per call of fn
+func measure(iterations int, fn func()) float64 {
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		fn()
+	}
+	return float64(time.Since(start).Nanoseconds()) / float64(iterations)
+}
+
+// writeJSON stores results as indented JSON
+func writeJSON(path string, results Results) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// compare prints the percentage change of every benchmark against the
+// baseline file and reports whether any slowed down beyond threshold
+func compare(path string, current Results, threshold float64) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	var baseline Results
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return false, err
+	}
+
+	names := make([]string, 0, len(current.NsPerOp))
+	for name := range current.NsPerOp {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("\nComparison with %s (%s, %s):\n", path, baseline.GoVersion, baseline.Platform)
+	regressed := false
+	for _, name := range names {
+		old, ok := baseline.NsPerOp[name]
+		if !ok || old <= 0 {
+			fmt.Printf("  %-8s %10.2f ns/op   (no baseline)\n", name, current.NsPerOp[name])
+			continue
+		}
+		delta := (current.NsPerOp[name] - old) / old * 100
+		marker := ""
+		if delta > threshold {
+			marker = "  ⚠️ regression"
+			regressed = true
+		}
+		fmt.Printf("  %-8s %10.2f ns/op   %+7.2f%%%s\n", name, current.NsPerOp[name], delta, marker)
+	}
+	return regressed, nil
 }
 
 func generateResults(iterations int, elapsed time.Duration) {
@@ -0,0 +1,66 @@
+package ulid
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGenerateBetween(t *testing.T) {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	for _, d := range []Distribution{DistributionUniform, DistributionBursty} {
+		ids, err := GenerateBetween(start, end, 1000, WithDistribution(d))
+		if err != nil {
+			t.Fatalf("Error generating ULIDs: %v", err)
+		}
+		if len(ids) != 1000 {
+			t.Fatalf("Count mismatch: got %d, expected 1000", len(ids))
+		}
+
+		strs := make([]string, len(ids))
+		for i, id := range ids {
+			ts := time.UnixMilli(int64(id.GetTime()))
+			if ts.Before(start) || ts.After(end) {
+				t.Fatalf("Timestamp %v outside window", ts)
+			}
+			strs[i] = id.String()
+		}
+
+		for i := 1; i < len(strs); i++ {
+			if strs[i] <= strs[i-1] {
+				t.Fatalf("ULIDs not strictly increasing at %d: %s <= %s", i, strs[i], strs[i-1])
+			}
+		}
+		if !slices.IsSorted(strs) {
+			t.Errorf("Expected sorted output for distribution %d", d)
+		}
+	}
+}
+
+func TestGenerateBetweenSameMillisecond(t *testing.T) {
+	at := time.UnixMilli(1678886400000)
+	ids, err := GenerateBetween(at, at, 10)
+	if err != nil {
+		t.Fatalf("Error generating ULIDs: %v", err)
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i].String() <= ids[i-1].String() {
+			t.Fatalf("Same-millisecond ULIDs not monotonic at %d", i)
+		}
+	}
+}
+
+func TestGenerateBetweenInvalid(t *testing.T) {
+	now := time.Now()
+	if _, err := GenerateBetween(now, now.Add(-time.Second), 1); err == nil {
+		t.Errorf("Expected error for end before start")
+	}
+	if _, err := GenerateBetween(now, now, -1); err == nil {
+		t.Errorf("Expected error for negative count")
+	}
+	if _, err := GenerateBetween(time.UnixMilli(-1), now, 1); err == nil {
+		t.Errorf("Expected error for timestamp before epoch")
+	}
+}
@@ -0,0 +1,63 @@
+package ulid
+
+import "io"
+
+// Bytes returns the canonical 16-byte big-endian form of the ULID, suitable
+// for BINARY(16) columns: the 48-bit timestamp followed by the 80-bit
+// randomness.
+func (u ULID) Bytes() [totalBytes]byte {
+	return u.bytes()
+}
+
+// FromBytes decodes the 16-byte big-endian form produced by Bytes.
+func FromBytes(b []byte) (ULID, error) {
+	if len(b) != totalBytes {
+		return ULID{}, lengthError(totalBytes, len(b))
+	}
+	return fromBytes([totalBytes]byte(b)), nil
+}
+
+// FromParts builds a ULID from a timestamp in Unix milliseconds and an 80-bit
+// randomness value, for reconstructing exact IDs, e.g. when replaying an
+// event log. It returns an error if the timestamp exceeds 48 bits.
+func FromParts(timestamp uint64, randomness [randomnessBytes]byte) (ULID, error) {
+	if timestamp > maxTimestamp {
+		return ULID{}, ErrTimestampOverflow
+	}
+	return ULID{timestamp: timestamp, randomness: randomness}, nil
+}
+
+// AppendBinary implements encoding.BinaryAppender, appending the 16-byte
+// big-endian form of the ULID to b.
+func (u ULID) AppendBinary(b []byte) ([]byte, error) {
+	data := u.bytes()
+	return append(b, data[:]...), nil
+}
+
+// AppendText implements encoding.TextAppender, appending the string form of
+// the ULID to b without allocating when b has enough capacity.
+func (u ULID) AppendText(b []byte) ([]byte, error) {
+	data := u.bytes()
+	var text [encodedLength]byte
+	encodeInto(stringTable(), &data, &text)
+	return append(b, text[:]...), nil
+}
+
+// WriteTo implements io.WriterTo, writing the 16-byte big-endian form of the
+// ULID to w.
+func (u ULID) WriteTo(w io.Writer) (int64, error) {
+	data := u.bytes()
+	n, err := w.Write(data[:])
+	return int64(n), err
+}
+
+// ReadULID reads one ULID in the 16-byte big-endian form from r. It returns
+// io.EOF if no bytes were read and io.ErrUnexpectedEOF if r ends part way
+// through a ULID.
+func ReadULID(r io.Reader) (ULID, error) {
+	var data [totalBytes]byte
+	if _, err := io.ReadFull(r, data[:]); err != nil {
+		return ULID{}, err
+	}
+	return fromBytes(data), nil
+}
@@ -0,0 +1,141 @@
+package ulid
+
+import (
+	"bytes"
+	"encoding"
+	"io"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryAppender = ULID{}
+	_ io.WriterTo             = ULID{}
+)
+
+func TestAppendBinary(t *testing.T) {
+	u := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+
+	buf := make([]byte, 2, 32)
+	out, err := u.AppendBinary(buf)
+	if err != nil {
+		t.Fatalf("Error appending binary: %v", err)
+	}
+
+	want := []byte{0, 0, 0x01, 0x86, 0xe5, 0x6d, 0x70, 0x00, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if !bytes.Equal(out, want) {
+		t.Errorf("AppendBinary mismatch: got %x, expected %x", out, want)
+	}
+	if &out[0] != &buf[0] {
+		t.Errorf("Expected AppendBinary to reuse the spare capacity")
+	}
+}
+
+func TestAppendText(t *testing.T) {
+	u := MustParse("01arz3ndektsv4rrffq69g5far")
+
+	out, err := u.AppendText([]byte("id="))
+	if err != nil {
+		t.Fatalf("Error appending text: %v", err)
+	}
+	if string(out) != "id=01arz3ndektsv4rrffq69g5far" {
+		t.Errorf("AppendText mismatch: got %s", out)
+	}
+
+	buf := make([]byte, 0, encodedLength)
+	if allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = u.AppendText(buf[:0])
+	}); allocs != 0 {
+		t.Errorf("Expected AppendText not to allocate, got %v allocations", allocs)
+	}
+}
+
+func TestBytes(t *testing.T) {
+	u := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+
+	data := u.Bytes()
+	want := []byte{0x01, 0x86, 0xe5, 0x6d, 0x70, 0x00, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if !bytes.Equal(data[:], want) {
+		t.Errorf("Bytes mismatch: got %x, expected %x", data, want)
+	}
+
+	back, err := FromBytes(data[:])
+	if err != nil {
+		t.Fatalf("Error decoding bytes: %v", err)
+	}
+	if back != u {
+		t.Errorf("Round trip failed: got %s, expected %s", back, u)
+	}
+
+	if _, err := FromBytes(data[:15]); err == nil {
+		t.Errorf("Expected error for short input")
+	}
+	if _, err := FromBytes(append(data[:], 0)); err == nil {
+		t.Errorf("Expected error for long input")
+	}
+}
+
+func TestFromParts(t *testing.T) {
+	randomness := [randomnessBytes]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	u, err := FromParts(1678886400000, randomness)
+	if err != nil {
+		t.Fatalf("Error building ULID: %v", err)
+	}
+	if u.GetTime() != 1678886400000 || u.Entropy() != randomness {
+		t.Errorf("FromParts mismatch: got %s", u.DebugString())
+	}
+
+	if _, err := FromParts(maxTimestamp+1, randomness); err == nil {
+		t.Errorf("Expected error for timestamp overflow")
+	}
+}
+
+func TestWriteToReadULID(t *testing.T) {
+	var buf bytes.Buffer
+	var want []ULID
+	for range 3 {
+		u, err := NewULID()
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		n, err := u.WriteTo(&buf)
+		if err != nil || n != 16 {
+			t.Fatalf("Error writing ULID: wrote %d bytes, %v", n, err)
+		}
+		want = append(want, u)
+	}
+
+	for _, u := range want {
+		got, err := ReadULID(&buf)
+		if err != nil {
+			t.Fatalf("Error reading ULID: %v", err)
+		}
+		if got != u {
+			t.Errorf("Read mismatch: got %s, expected %s", got, u)
+		}
+	}
+
+	if _, err := ReadULID(&buf); err != io.EOF {
+		t.Errorf("Expected io.EOF at end of stream, got %v", err)
+	}
+	if _, err := ReadULID(bytes.NewReader(make([]byte, 7))); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF for truncated input, got %v", err)
+	}
+}
+
+func BenchmarkAppendBinary(b *testing.B) {
+	u, _ := Parse("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	buf := make([]byte, 0, 16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = u.AppendBinary(buf[:0])
+	}
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	u, _ := Parse("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	buf := make([]byte, 0, encodedLength)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = u.AppendText(buf[:0])
+	}
+}
@@ -0,0 +1,32 @@
+package ulid
+
+import (
+	"errors"
+	"time"
+)
+
+// PartitionBoundaries returns count+1 ULIDs marking the boundaries of count
+// consecutive time ranges of the given interval starting at start, for
+// declaring range partitions on ULID-keyed tables. Each boundary is the
+// smallest ULID of its millisecond, so range i holds exactly the IDs in
+// [boundaries[i], boundaries[i+1]).
+func PartitionBoundaries(start time.Time, interval time.Duration, count int) ([]ULID, error) {
+	if interval < time.Millisecond {
+		return nil, errors.New("interval must be at least one millisecond")
+	}
+	if count < 1 {
+		return nil, errors.New("count must be at least 1")
+	}
+
+	first := start.UnixMilli()
+	last := start.Add(interval * time.Duration(count)).UnixMilli()
+	if first < 0 || last > maxTimestamp {
+		return nil, ErrTimestampOverflow
+	}
+
+	boundaries := make([]ULID, count+1)
+	for i := range boundaries {
+		boundaries[i] = ULID{timestamp: uint64(start.Add(interval * time.Duration(i)).UnixMilli())}
+	}
+	return boundaries, nil
+}
@@ -0,0 +1,39 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPartitionBoundaries(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	boundaries, err := PartitionBoundaries(start, 24*time.Hour, 90)
+	if err != nil {
+		t.Fatalf("Error computing boundaries: %v", err)
+	}
+	if len(boundaries) != 91 {
+		t.Fatalf("Boundary count mismatch: got %d, expected 91", len(boundaries))
+	}
+
+	for i := 1; i < len(boundaries); i++ {
+		if boundaries[i].String() <= boundaries[i-1].String() {
+			t.Fatalf("Boundaries not increasing at %d", i)
+		}
+	}
+
+	// An ID generated at the last millisecond of a day belongs to that day's range
+	ulidStr, err := NewTime(uint64(start.Add(24*time.Hour - time.Millisecond).UnixMilli()))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if ulidStr < boundaries[0].String() || ulidStr >= boundaries[1].String() {
+		t.Errorf("ULID %s not within the first partition", ulidStr)
+	}
+
+	if _, err := PartitionBoundaries(start, 0, 1); err == nil {
+		t.Errorf("Expected error for zero interval")
+	}
+	if _, err := PartitionBoundaries(start, time.Hour, 0); err == nil {
+		t.Errorf("Expected error for zero count")
+	}
+}
@@ -0,0 +1,61 @@
+package ulid
+
+import "time"
+
+// maxULID is the largest representable ULID. The package uses it instead of
+// Max so that reassigning the exported variable cannot change its behavior.
+var maxULID = ULID{
+	timestamp:  maxTimestamp,
+	randomness: [randomnessBytes]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+}
+
+// Zero and Max are variables only because Go has no struct constants; they
+// must not be reassigned. The package itself never reads them.
+var (
+	// Zero is the zero ULID, which sorts before every other ULID. It can be
+	// used as an unset value and as the open lower bound of a range scan.
+	Zero = ULID{}
+
+	// Max is the largest representable ULID, which sorts after every other
+	// ULID. It can be used as the open upper bound of a range scan.
+	Max = maxULID
+)
+
+// IsZero reports whether u is the zero ULID.
+func (u ULID) IsZero() bool {
+	return u == ULID{}
+}
+
+// MaxTime returns the latest time a ULID can represent, in UTC.
+func MaxTime() time.Time {
+	return time.UnixMilli(MaxTimestamp).UTC()
+}
+
+// MinForTime returns the smallest ULID in the millisecond of t (all-zero
+// randomness), for use as the inclusive lower bound of a time range scan.
+// Times before the Unix epoch or beyond the 48-bit range are clamped.
+func MinForTime(t time.Time) ULID {
+	return ULID{timestamp: clampTimestamp(t)}
+}
+
+// MaxForTime returns the largest ULID in the millisecond of t (all-ones
+// randomness), for use as the inclusive upper bound of a time range scan.
+// Times before the Unix epoch or beyond the 48-bit range are clamped.
+func MaxForTime(t time.Time) ULID {
+	u := maxULID
+	u.timestamp = clampTimestamp(t)
+	return u
+}
+
+// clampTimestamp converts t to Unix milliseconds within the 48-bit range
+func clampTimestamp(t time.Time) uint64 {
+	ms := t.UnixMilli()
+	switch {
+	case ms < 0:
+		return 0
+	case ms > maxTimestamp:
+		return maxTimestamp
+	default:
+		return uint64(ms)
+	}
+}
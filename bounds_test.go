@@ -0,0 +1,91 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestZeroAndMax(t *testing.T) {
+	if !Zero.IsZero() {
+		t.Errorf("Expected Zero to be zero")
+	}
+	if Max.IsZero() {
+		t.Errorf("Expected Max to be non-zero")
+	}
+
+	u, err := NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if Zero.Compare(u) >= 0 || Max.Compare(u) <= 0 {
+		t.Errorf("Expected Zero < %s < Max", u)
+	}
+
+	for _, bound := range []ULID{Zero, Max} {
+		parsed, err := Parse(bound.String())
+		if err != nil {
+			t.Fatalf("Error parsing %s: %v", bound, err)
+		}
+		if parsed != bound {
+			t.Errorf("Round trip failed: got %s, expected %s", parsed, bound)
+		}
+	}
+}
+
+func TestBoundsIgnoreReassignedVariables(t *testing.T) {
+	zero, max := Zero, Max
+	defer func() { Zero, Max = zero, max }()
+	Zero, Max = max, zero
+
+	if !(ULID{}).IsZero() {
+		t.Errorf("Expected the zero value to be zero after reassigning Zero")
+	}
+	if got := MaxForTime(time.UnixMilli(1)); got.Entropy() != max.Entropy() {
+		t.Errorf("Expected all-ones randomness after reassigning Max, got %x", got.Entropy())
+	}
+}
+
+func TestMinMaxForTime(t *testing.T) {
+	start := time.UnixMilli(1678886400000)
+	end := start.Add(time.Hour)
+
+	lower, upper := MinForTime(start), MaxForTime(end)
+	for i := range 10 {
+		u, err := NewULIDTime(uint64(start.Add(time.Duration(i) * 6 * time.Minute).UnixMilli()))
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		if u.Compare(lower) < 0 || u.Compare(upper) > 0 {
+			t.Errorf("ULID %s outside [%s, %s]", u, lower, upper)
+		}
+	}
+
+	if MinForTime(start).GetTime() != 1678886400000 || MinForTime(start).Entropy() != Zero.Entropy() {
+		t.Errorf("MinForTime mismatch: got %s", MinForTime(start).DebugString())
+	}
+	if MaxForTime(start).Entropy() != Max.Entropy() {
+		t.Errorf("MaxForTime mismatch: got %s", MaxForTime(start).DebugString())
+	}
+
+	if MinForTime(time.UnixMilli(-5)) != Zero {
+		t.Errorf("Expected pre-epoch time to clamp to Zero")
+	}
+	if MaxForTime(time.UnixMilli(maxTimestamp+5)) != Max {
+		t.Errorf("Expected far-future time to clamp to Max")
+	}
+}
+
+func TestSizeConstants(t *testing.T) {
+	if len(Max.String()) != EncodedSize {
+		t.Errorf("EncodedSize mismatch: %d", EncodedSize)
+	}
+	if data := Max.Bytes(); len(data) != BinarySize {
+		t.Errorf("BinarySize mismatch: %d", BinarySize)
+	}
+	if Max.GetTime() != MaxTimestamp || MaxTimestamp != 1<<TimestampBits-1 {
+		t.Errorf("MaxTimestamp mismatch: %d", uint64(MaxTimestamp))
+	}
+	if !MaxTime().Equal(Max.Time()) {
+		t.Errorf("MaxTime mismatch: got %v, expected %v", MaxTime(), Max.Time())
+	}
+}
@@ -0,0 +1,138 @@
+package ulid
+
+import (
+	"errors"
+	"sync"
+)
+
+// Buffer keeps a ring of pre-generated ULID strings that a background
+// goroutine tops up, so latency-critical paths can take an ID without
+// waiting on the entropy source or the generator lock.
+//
+// When the number of buffered IDs falls below the low watermark, the
+// background goroutine refills the ring up to the high watermark. If the
+// ring is empty, Get falls back to generating an ID synchronously. IDs taken
+// from a Buffer were generated ahead of time, so their timestamps may lag the
+// moment Get was called, and successive Get results are not guaranteed to be
+// in sorted order once the fallback path has been taken.
+type Buffer struct {
+	mu    sync.Mutex
+	ring  []string
+	head  int // index of the oldest buffered ID
+	count int
+
+	low, high int
+	wake      chan struct{}
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewBuffer creates a Buffer holding up to capacity IDs and starts its
+// refill goroutine. The ring is refilled to high once it drops below low;
+// 0 <= low < high <= capacity must hold. Call Close to stop the goroutine.
+func NewBuffer(capacity, low, high int) (*Buffer, error) {
+	if capacity < 1 {
+		return nil, errors.New("buffer capacity must be positive")
+	}
+	if low < 0 || low >= high || high > capacity {
+		return nil, errors.New("buffer watermarks must satisfy 0 <= low < high <= capacity")
+	}
+
+	b := &Buffer{
+		ring: make([]string, capacity),
+		low:  low,
+		high: high,
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	b.wake <- struct{}{}
+	b.wg.Add(1)
+	go b.refill()
+	return b, nil
+}
+
+// Get returns the oldest buffered ID, or a freshly generated one when the
+// buffer is empty.
+func (b *Buffer) Get() (string, error) {
+	b.mu.Lock()
+	if b.count == 0 {
+		b.mu.Unlock()
+		b.signal()
+		return New()
+	}
+	id := b.ring[b.head]
+	b.ring[b.head] = ""
+	b.head = (b.head + 1) % len(b.ring)
+	b.count--
+	low := b.count < b.low
+	b.mu.Unlock()
+
+	if low {
+		b.signal()
+	}
+	return id, nil
+}
+
+// Len returns the number of IDs currently buffered.
+func (b *Buffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count
+}
+
+// Close stops the refill goroutine and waits for it to exit. Get keeps
+// working after Close, generating IDs synchronously once the buffer drains.
+func (b *Buffer) Close() {
+	b.closeOnce.Do(func() {
+		close(b.done)
+	})
+	b.wg.Wait()
+}
+
+// signal wakes the refill goroutine without blocking
+func (b *Buffer) signal() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// refill tops the ring up to the high watermark each time it is woken
+func (b *Buffer) refill() {
+	defer b.wg.Done()
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-b.wake:
+		}
+
+		for {
+			b.mu.Lock()
+			full := b.count >= b.high
+			b.mu.Unlock()
+			if full {
+				break
+			}
+
+			id, err := New()
+			if err != nil {
+				// Leave the buffer short; Get surfaces the error once it
+				// falls back to synchronous generation.
+				break
+			}
+
+			b.mu.Lock()
+			b.ring[(b.head+b.count)%len(b.ring)] = id
+			b.count++
+			b.mu.Unlock()
+
+			select {
+			case <-b.done:
+				return
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuffer(t *testing.T) {
+	if _, err := NewBuffer(0, 0, 0); err == nil {
+		t.Errorf("Expected error for zero capacity")
+	}
+	if _, err := NewBuffer(8, 4, 4); err == nil {
+		t.Errorf("Expected error for low watermark not below high watermark")
+	}
+	if _, err := NewBuffer(8, 2, 9); err == nil {
+		t.Errorf("Expected error for high watermark above capacity")
+	}
+
+	buffer, err := NewBuffer(64, 16, 48)
+	if err != nil {
+		t.Fatalf("Error creating buffer: %v", err)
+	}
+	defer buffer.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for buffer.Len() < 48 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := buffer.Len(); got != 48 {
+		t.Fatalf("Expected buffer to fill to high watermark, got %d", got)
+	}
+
+	seen := make(map[string]bool)
+	for range 200 {
+		ulidStr, err := buffer.Get()
+		if err != nil {
+			t.Fatalf("Error getting ULID: %v", err)
+		}
+		if _, err := Parse(ulidStr); err != nil {
+			t.Fatalf("Buffered ULID %q does not parse: %v", ulidStr, err)
+		}
+		if seen[ulidStr] {
+			t.Fatalf("Duplicate ULID from buffer: %s", ulidStr)
+		}
+		seen[ulidStr] = true
+	}
+
+	buffer.Close()
+	if _, err := buffer.Get(); err != nil {
+		t.Errorf("Expected Get to keep working after Close, got %v", err)
+	}
+}
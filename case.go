@@ -0,0 +1,47 @@
+package ulid
+
+import "sync/atomic"
+
+var (
+	// upperEncodeTable is encodeTable with letters in upper case
+	upperEncodeTable [32]byte
+
+	// uppercase makes String emit upper case when set
+	uppercase atomic.Bool
+)
+
+func init() {
+	for i, c := range encodeTable {
+		upperEncodeTable[i] = lowerToUpper(c)
+	}
+}
+
+// SetUppercase selects whether String, and therefore New, NewTime and the
+// text and JSON encodings, emit upper case letters for the whole process.
+// The default is lower case. Parsing is case-insensitive either way.
+func SetUppercase(enabled bool) {
+	uppercase.Store(enabled)
+}
+
+// UpperString returns the string representation of the ULID in upper case,
+// as in the examples of the ULID specification, regardless of SetUppercase.
+func (u ULID) UpperString() string {
+	data := u.bytes()
+	return ultraFastEncode(&upperEncodeTable, &data)
+}
+
+// stringTable returns the encoding table selected by SetUppercase
+func stringTable() *[32]byte {
+	if uppercase.Load() {
+		return &upperEncodeTable
+	}
+	return &encodeTable
+}
+
+// lowerToUpper folds an ASCII lower-case letter to upper case
+func lowerToUpper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - ('a' - 'A')
+	}
+	return c
+}
@@ -0,0 +1,43 @@
+package ulid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpperString(t *testing.T) {
+	defer SetUppercase(false)
+
+	u, err := NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	lower := u.String()
+	if got := u.UpperString(); got != strings.ToUpper(lower) {
+		t.Errorf("UpperString mismatch: got %s, expected %s", got, strings.ToUpper(lower))
+	}
+
+	SetUppercase(true)
+	if got := u.String(); got != strings.ToUpper(lower) {
+		t.Errorf("Expected upper case String, got %s", got)
+	}
+	ulidStr, err := New()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if ulidStr != strings.ToUpper(ulidStr) {
+		t.Errorf("Expected upper case New output, got %s", ulidStr)
+	}
+	parsed, err := Parse(u.String())
+	if err != nil {
+		t.Fatalf("Error parsing upper case ULID: %v", err)
+	}
+	if parsed != u {
+		t.Errorf("Round trip failed: got %s, expected %s", parsed, u)
+	}
+
+	SetUppercase(false)
+	if got := u.String(); got != lower {
+		t.Errorf("Expected lower case String, got %s", got)
+	}
+}
@@ -0,0 +1,90 @@
+package ulid
+
+import "fmt"
+
+// checkAlphabet holds the Crockford check symbols for the values 0 to 36
+const checkAlphabet = "0123456789abcdefghjkmnpqrstvwxyz*~$=u"
+
+// checkModulus is the modulus of the two-symbol checksum
+const checkModulus = 37 * 37
+
+// CheckedSize is the length of a ULID string with a checksum suffix.
+const CheckedSize = encodedLength + 2
+
+// CheckedString returns the ULID followed by a two-character checksum, for
+// IDs that are read out or typed by humans. The checksum is the value of the
+// 26-character string, read as a Crockford Base32 number, modulo 37²,
+// written as two Crockford check symbols. The last character is therefore
+// the standard Crockford check symbol (the value modulo 37), which detects
+// any single-character substitution and any transposition of adjacent
+// characters; the first extends the check to catch more multi-character
+// errors.
+func (u ULID) CheckedString() string {
+	s := u.String()
+	sum := checksum(s)
+
+	var buf [CheckedSize]byte
+	copy(buf[:], s)
+	buf[encodedLength] = checkAlphabet[sum/37]
+	buf[encodedLength+1] = checkAlphabet[sum%37]
+	return string(buf[:])
+}
+
+// ParseChecked parses a ULID string produced by CheckedString, verifying
+// its checksum. Like Parse, it is case-insensitive and accepts the Crockford
+// aliases I, L, O and U in the ULID. A character outside the check alphabet
+// is reported as an *InvalidCharacterError and a checksum that does not match
+// as an error wrapping ErrChecksum.
+func ParseChecked(s string) (ULID, error) {
+	if len(s) != CheckedSize {
+		return ULID{}, lengthError(CheckedSize, len(s))
+	}
+
+	u, err := Parse(s[:encodedLength])
+	if err != nil {
+		return ULID{}, err
+	}
+
+	var sum int
+	for i := encodedLength; i < CheckedSize; i++ {
+		v, ok := checkValue(s[i])
+		if !ok {
+			return ULID{}, &InvalidCharacterError{Pos: i, Char: s[i]}
+		}
+		sum = sum*37 + v
+	}
+	if want := checksum(s[:encodedLength]); sum != want {
+		return ULID{}, fmt.Errorf("%w: got %q, expected %q", ErrChecksum, s[encodedLength:],
+			[]byte{checkAlphabet[want/37], checkAlphabet[want%37]})
+	}
+	return u, nil
+}
+
+// checksum returns the value of a valid ULID string modulo checkModulus
+func checksum(s string) int {
+	sum := 0
+	for i := range len(s) {
+		sum = (sum*32 + int(decodeTable[s[i]])) % checkModulus
+	}
+	return sum
+}
+
+// checkValue decodes a Crockford check symbol
+func checkValue(c byte) (int, bool) {
+	switch c {
+	case '*':
+		return 32, true
+	case '~':
+		return 33, true
+	case '$':
+		return 34, true
+	case '=':
+		return 35, true
+	case 'u', 'U':
+		return 36, true
+	}
+	if v := decodeTable[c]; v != 0xFF {
+		return int(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,78 @@
+package ulid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckedString(t *testing.T) {
+	for range 100 {
+		u, err := NewULID()
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+
+		checked := u.CheckedString()
+		if len(checked) != CheckedSize || !strings.HasPrefix(checked, u.String()) {
+			t.Fatalf("Unexpected checked form %q for %s", checked, u)
+		}
+
+		for _, input := range []string{checked, strings.ToUpper(checked)} {
+			parsed, err := ParseChecked(input)
+			if err != nil {
+				t.Fatalf("Error parsing %q: %v", input, err)
+			}
+			if parsed != u {
+				t.Errorf("Round trip failed: got %s, expected %s", parsed, u)
+			}
+		}
+	}
+}
+
+func TestParseCheckedDetectsErrors(t *testing.T) {
+	u, err := NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	checked := u.CheckedString()
+
+	// Every single-character substitution must be detected
+	for i := range encodedLength {
+		for _, c := range encodeTable {
+			if c == checked[i] {
+				continue
+			}
+			typo := checked[:i] + string(c) + checked[i+1:]
+			if _, err := ParseChecked(typo); err == nil {
+				t.Fatalf("Substitution at %d not detected: %s", i, typo)
+			}
+		}
+	}
+
+	// Every transposition of adjacent, distinct characters must be detected
+	for i := range encodedLength - 1 {
+		if checked[i] == checked[i+1] {
+			continue
+		}
+		typo := checked[:i] + string(checked[i+1]) + string(checked[i]) + checked[i+2:]
+		if _, err := ParseChecked(typo); err == nil {
+			t.Fatalf("Transposition at %d not detected: %s", i, typo)
+		}
+	}
+
+	if _, err := ParseChecked(u.String()); err == nil {
+		t.Errorf("Expected error for missing checksum")
+	}
+	_, err = ParseChecked(u.String() + "0!")
+	var charErr *InvalidCharacterError
+	if !errors.As(err, &charErr) || charErr.Pos != encodedLength+1 || charErr.Char != '!' {
+		t.Errorf("Expected InvalidCharacterError for the check symbol, got %v", err)
+	}
+
+	last, _ := checkValue(checked[encodedLength+1])
+	mismatch := checked[:encodedLength+1] + string(checkAlphabet[(last+1)%37])
+	if _, err := ParseChecked(mismatch); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected ErrChecksum for %s, got %v", mismatch, err)
+	}
+}
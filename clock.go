@@ -0,0 +1,110 @@
+package ulid
+
+import (
+	"fmt"
+	"time"
+)
+
+// OnClockBackwards registers fn to be called whenever New observes the wall
+// clock reporting an earlier millisecond than the previous call, for example
+// after an NTP step or a VM migration. The previous and current timestamps are
+// passed in milliseconds. The hook runs after the ULID has been generated, or
+// before ErrClockRegression is returned, outside of any internal lock; passing
+// nil removes it. See ClockRegressionPolicy for how the ID itself is affected.
+//
+// Timestamps supplied explicitly through NewTime are never reported.
+func OnClockBackwards(fn func(previous, current uint64)) {
+	Default().OnClockBackwards(fn)
+}
+
+// OnClockBackwards registers a clock regression hook on g, as with the
+// package-level OnClockBackwards.
+func (g *Generator) OnClockBackwards(fn func(previous, current uint64)) {
+	if fn == nil {
+		g.clockBackwardsHook.Store(nil)
+		return
+	}
+	g.clockBackwardsHook.Store(&fn)
+}
+
+// ClockRegressionPolicy determines what a Generator does when New reads a
+// wall-clock millisecond earlier than the previous read, e.g. after an NTP
+// step or a VM migration. The regression is reported to the OnClockBackwards
+// hook and counted in GeneratorStats.ClockRegressions under every policy.
+// Timestamps supplied explicitly through NewTime are not subject to it.
+type ClockRegressionPolicy int
+
+const (
+	// ClockRegressionMonotonic keeps the generator's clock from going
+	// backwards: while the wall clock is behind, time advances from the last
+	// good reading by Go's monotonic clock, which is unaffected by wall-clock
+	// steps, and never falls below the last millisecond used. IDs keep
+	// sorting in generation order and timestamps keep advancing at the real
+	// rate; GeneratorStats.ClockSkew reports how far they run ahead of the
+	// wall clock until it catches up. Clocks set with WithClock rarely carry
+	// monotonic readings, in which case the timestamp is held at the last
+	// millisecond used. This is the default.
+	ClockRegressionMonotonic ClockRegressionPolicy = iota
+
+	// ClockRegressionAllow uses the regressed clock as is, so IDs generated
+	// until the clock catches up sort before IDs already issued.
+	ClockRegressionAllow
+
+	// ClockRegressionClamp holds the timestamp at the last one issued and
+	// keeps incrementing the randomness until the clock catches up, so IDs
+	// stay strictly increasing. Timestamps may run ahead of the clock, and
+	// timestamp jitter cannot move an ID before its predecessor.
+	ClockRegressionClamp
+
+	// ClockRegressionError fails the call with ErrClockRegression until the
+	// clock reaches the last millisecond it reported.
+	ClockRegressionError
+)
+
+// String returns the name of the policy.
+func (p ClockRegressionPolicy) String() string {
+	switch p {
+	case ClockRegressionMonotonic:
+		return "monotonic"
+	case ClockRegressionAllow:
+		return "allow"
+	case ClockRegressionClamp:
+		return "clamp"
+	case ClockRegressionError:
+		return "error"
+	default:
+		return fmt.Sprintf("ClockRegressionPolicy(%d)", int(p))
+	}
+}
+
+// WithClockRegressionPolicy sets how the Generator handles the wall clock
+// going backwards. The default is ClockRegressionMonotonic.
+func WithClockRegressionPolicy(policy ClockRegressionPolicy) GeneratorOption {
+	return func(g *Generator) {
+		g.clockPolicy = policy
+	}
+}
+
+// clockRegressed records a clock regression from previous to current
+func (g *Generator) clockRegressed(previous, current uint64) {
+	g.stats.clockRegressions.Add(1)
+	if hook := g.clockBackwardsHook.Load(); hook != nil {
+		(*hook)(g.epoch+previous, g.epoch+current)
+	}
+}
+
+// monotonicClock returns the effective time of the wall-clock reading now
+// under ClockRegressionMonotonic, given the anchor of the previous reading,
+// and the anchor to carry forward. While now is behind the anchor's wall time
+// advanced by the monotonic time elapsed since, the latter is used.
+func monotonicClock(anchor, now time.Time) (effective, nextAnchor time.Time) {
+	if !anchor.IsZero() {
+		// Sub uses the monotonic readings when both times carry one; the
+		// comparison must use the wall times
+		derived := anchor.Add(now.Sub(anchor))
+		if now.UnixNano() < derived.UnixNano() {
+			return derived, anchor
+		}
+	}
+	return now, now
+}
@@ -0,0 +1,162 @@
+package ulid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOnClockBackwards(t *testing.T) {
+	current := time.Now()
+	timeNow = func() time.Time { return current }
+	defer func() { timeNow = time.Now }()
+
+	var calls int
+	var gotPrevious, gotCurrent uint64
+	OnClockBackwards(func(previous, current uint64) {
+		calls++
+		gotPrevious, gotCurrent = previous, current
+	})
+	defer OnClockBackwards(nil)
+
+	if _, err := New(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("Hook called without a clock regression")
+	}
+
+	previous := current
+	current = current.Add(-2 * time.Second)
+	if _, err := New(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+
+	if calls != 1 {
+		t.Fatalf("Hook call count mismatch: got %d, expected 1", calls)
+	}
+	if gotPrevious != uint64(previous.UnixMilli()) || gotCurrent != uint64(current.UnixMilli()) {
+		t.Errorf("Hook timestamps mismatch: got %d -> %d, expected %d -> %d",
+			gotPrevious, gotCurrent, previous.UnixMilli(), current.UnixMilli())
+	}
+
+	// Explicit timestamps never count as clock regressions
+	if _, err := NewTime(0); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Hook called for an explicit timestamp")
+	}
+}
+
+func TestClockRegressionsStat(t *testing.T) {
+	current := time.Now()
+	g := NewGenerator(WithClock(func() time.Time { return current }))
+
+	for _, offset := range []time.Duration{0, -time.Second, time.Second} {
+		current = current.Add(offset)
+		if _, err := g.New(); err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+	}
+	if got := g.Stats().ClockRegressions; got != 1 {
+		t.Errorf("ClockRegressions mismatch: got %d, expected 1", got)
+	}
+}
+
+func TestClockRegressionPolicy(t *testing.T) {
+	start := time.Now()
+	for _, policy := range []ClockRegressionPolicy{ClockRegressionMonotonic, ClockRegressionAllow, ClockRegressionClamp, ClockRegressionError} {
+		t.Run(policy.String(), func(t *testing.T) {
+			current := start
+			var regressions int
+			g := NewGenerator(
+				WithClock(func() time.Time { return current }),
+				WithClockRegressionPolicy(policy),
+				WithClockBackwardsHook(func(previous, current uint64) { regressions++ }),
+			)
+
+			first, err := g.NewULID()
+			if err != nil {
+				t.Fatalf("Error generating ULID: %v", err)
+			}
+
+			current = start.Add(-time.Second)
+			for range 2 {
+				u, err := g.NewULID()
+				switch policy {
+				case ClockRegressionAllow:
+					if err != nil || u.Compare(first) >= 0 {
+						t.Errorf("Expected an ID before %s, got %s, %v", first, u, err)
+					}
+				case ClockRegressionMonotonic, ClockRegressionClamp:
+					if err != nil || u.Compare(first) <= 0 || u.GetTime() != first.GetTime() {
+						t.Errorf("Expected an ID after %s in its millisecond, got %s, %v", first, u, err)
+					}
+					first = u
+				case ClockRegressionError:
+					if !errors.Is(err, ErrClockRegression) {
+						t.Errorf("Expected ErrClockRegression, got %v", err)
+					}
+				}
+			}
+
+			wantRegressions := 1
+			if policy == ClockRegressionError {
+				wantRegressions = 2
+			}
+			if regressions != wantRegressions {
+				t.Errorf("Hook call count mismatch: got %d, expected %d", regressions, wantRegressions)
+			}
+
+			current = start.Add(time.Millisecond)
+			if _, err := g.NewULID(); err != nil {
+				t.Errorf("Error generating ULID after the clock caught up: %v", err)
+			}
+		})
+	}
+}
+
+func TestClockRegressionMonotonicSkew(t *testing.T) {
+	start := time.Now()
+	current := start
+	g := NewGenerator(WithClock(func() time.Time { return current }))
+
+	if _, err := g.New(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if skew := g.Stats().ClockSkew; skew != 0 {
+		t.Errorf("Expected no skew, got %v", skew)
+	}
+
+	current = start.Add(-time.Second)
+	if _, err := g.New(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if skew := g.Stats().ClockSkew; skew < time.Second-time.Millisecond || skew > time.Second+time.Millisecond {
+		t.Errorf("Expected about 1s of skew after the regression, got %v", skew)
+	}
+
+	current = start.Add(time.Second)
+	if _, err := g.New(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if skew := g.Stats().ClockSkew; skew != 0 {
+		t.Errorf("Expected no skew once the clock caught up, got %v", skew)
+	}
+}
+
+func TestMonotonicClock(t *testing.T) {
+	anchor := time.Now()
+	later := anchor.Add(5 * time.Millisecond)
+
+	effective, next := monotonicClock(time.Time{}, anchor)
+	if !effective.Equal(anchor) || !next.Equal(anchor) {
+		t.Errorf("Expected the first reading to anchor the clock")
+	}
+
+	effective, next = monotonicClock(anchor, later)
+	if effective.UnixNano() != later.UnixNano() || next.UnixNano() != later.UnixNano() {
+		t.Errorf("Expected a forward reading to re-anchor the clock")
+	}
+}
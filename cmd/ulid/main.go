@@ -7,14 +7,28 @@ import (
 	"os"
 
 	ulid "github.com/cloudresty/ulid"
+	"github.com/cloudresty/ulid/ulidsql"
 )
 
 var (
 	versionFlag = flag.Bool("version", false, "Print version information")
 	timeFlag    = flag.Uint64("time", 0, "Generate ULID with specified timestamp (milliseconds)")
+	sqlFlag     = flag.String("sql", "", "Print SQL function definitions for a dialect (postgres, mysql, sqlite)")
 	version     string // This will be set during build
 )
 
+// isFlagSet reports whether the named flag was explicitly provided on the
+// command line, so that zero values can be told apart from omitted flags.
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
 func main() {
 
 	flag.Parse()
@@ -24,10 +38,23 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *sqlFlag != "" {
+		dialect, err := ulidsql.ParseDialect(*sqlFlag)
+		if err != nil {
+			log.Fatalf("Error generating SQL: %v", err)
+		}
+		script, err := ulidsql.Functions(dialect)
+		if err != nil {
+			log.Fatalf("Error generating SQL: %v", err)
+		}
+		fmt.Print(script)
+		os.Exit(0)
+	}
+
 	var ulidStr string
 	var err error
 
-	if *timeFlag > 0 {
+	if isFlagSet("time") {
 		ulidStr, err = ulid.NewTime(*timeFlag)
 	} else {
 		ulidStr, err = ulid.New()
@@ -0,0 +1,157 @@
+// Command ulidgen generates strongly-typed ULID identifier types.
+//
+// Typical use is from a go:generate directive:
+//
+//	//go:generate ulidgen -type User,Order
+//
+// which writes ulid_ids.go declaring UserID and OrderID with constructors,
+// parse functions, and text, JSON and database/sql marshaling.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+var (
+	typeFlag    = flag.String("type", "", "Comma-separated list of entity names, e.g. User,Order")
+	packageFlag = flag.String("package", "", "Package name of the generated file (defaults to $GOPACKAGE)")
+	outputFlag  = flag.String("output", "ulid_ids.go", "Output file name")
+)
+
+func main() {
+
+	flag.Parse()
+
+	pkg := *packageFlag
+	if pkg == "" {
+		pkg = os.Getenv("GOPACKAGE")
+	}
+	if pkg == "" {
+		log.Fatalf("Error generating IDs: package name not set, use -package or run via go generate")
+	}
+
+	src, err := generate(pkg, strings.Split(*typeFlag, ","))
+	if err != nil {
+		log.Fatalf("Error generating IDs: %v", err)
+	}
+
+	if err := os.WriteFile(*outputFlag, src, 0644); err != nil {
+		log.Fatalf("Error writing %s: %v", *outputFlag, err)
+	}
+
+}
+
+// generate renders and formats the source for the given entity names.
+func generate(pkg string, entities []string) ([]byte, error) {
+	var types []string
+	for _, entity := range entities {
+		entity = strings.TrimSpace(entity)
+		if entity == "" {
+			continue
+		}
+		if !token.IsIdentifier(entity) {
+			return nil, fmt.Errorf("invalid type name %q", entity)
+		}
+		types = append(types, entity)
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("no types given, use -type")
+	}
+
+	var buf bytes.Buffer
+	err := fileTemplate.Execute(&buf, struct {
+		Package string
+		Types   []string
+	}{pkg, types})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+var fileTemplate = template.Must(template.New("ids").Parse(`// Code generated by ulidgen; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/cloudresty/ulid"
+)
+{{range .Types}}
+// {{.}}ID is a ULID that identifies a {{.}}.
+// It implements encoding.TextMarshaler (and therefore JSON), sql.Scanner and driver.Valuer.
+type {{.}}ID ulid.ULID
+
+// New{{.}}ID returns a new {{.}}ID.
+func New{{.}}ID() ({{.}}ID, error) {
+	u, err := ulid.NewULID()
+	if err != nil {
+		return {{.}}ID{}, err
+	}
+	return {{.}}ID(u), nil
+}
+
+// Parse{{.}}ID parses a ULID string into a {{.}}ID.
+func Parse{{.}}ID(s string) ({{.}}ID, error) {
+	u, err := ulid.Parse(s)
+	if err != nil {
+		return {{.}}ID{}, err
+	}
+	return {{.}}ID(u), nil
+}
+
+// ULID returns the underlying ULID.
+func (id {{.}}ID) ULID() ulid.ULID {
+	return ulid.ULID(id)
+}
+
+// String returns the canonical string representation of the ID.
+func (id {{.}}ID) String() string {
+	return ulid.ULID(id).String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id {{.}}ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *{{.}}ID) UnmarshalText(text []byte) error {
+	parsed, err := Parse{{.}}ID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (id {{.}}ID) Value() (driver.Value, error) {
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner. It accepts the string form and the 16-byte
+// binary form stored in BINARY(16) columns, like ulid.NullULID.
+func (id *{{.}}ID) Scan(src any) error {
+	var n ulid.NullULID
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	if !n.Valid {
+		return fmt.Errorf("cannot scan NULL into {{.}}ID")
+	}
+	*id = {{.}}ID(n.ULID)
+	return nil
+}
+{{end}}`))
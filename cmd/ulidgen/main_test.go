@@ -0,0 +1,120 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("models", []string{"User", " Order", ""})
+	if err != nil {
+		t.Fatalf("Error generating IDs: %v", err)
+	}
+
+	for _, want := range []string{
+		"package models",
+		"type UserID ulid.ULID",
+		"func NewOrderID() (OrderID, error)",
+		"func ParseUserID(s string) (UserID, error)",
+		"func (id *OrderID) Scan(src any) error",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("Generated source missing %q", want)
+		}
+	}
+}
+
+func TestGenerateInvalidType(t *testing.T) {
+	if _, err := generate("models", []string{"User-Order"}); err == nil {
+		t.Errorf("Expected error for invalid type name")
+	}
+	if _, err := generate("models", nil); err == nil {
+		t.Errorf("Expected error when no types are given")
+	}
+}
+
+// modelsTest exercises the generated types inside the generated package
+const modelsTest = `package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeneratedIDs(t *testing.T) {
+	id, err := NewUserID()
+	if err != nil {
+		t.Fatalf("Error generating ID: %v", err)
+	}
+
+	data, err := json.Marshal(id)
+	if err != nil {
+		t.Fatalf("Error marshaling ID: %v", err)
+	}
+	var decoded UserID
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != id {
+		t.Errorf("JSON round trip mismatch: got %v, %v", decoded, err)
+	}
+
+	value, err := id.Value()
+	if err != nil {
+		t.Fatalf("Error getting value: %v", err)
+	}
+	var scanned UserID
+	if err := scanned.Scan(value); err != nil || scanned != id {
+		t.Errorf("String scan mismatch: got %v, %v", scanned, err)
+	}
+	binary := id.ULID().Bytes()
+	scanned = UserID{}
+	if err := scanned.Scan(binary[:]); err != nil || scanned != id {
+		t.Errorf("Binary scan mismatch: got %v, %v", scanned, err)
+	}
+	if err := scanned.Scan(nil); err == nil {
+		t.Errorf("Expected error scanning NULL")
+	}
+}
+`
+
+func TestGenerateCompiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping go toolchain invocation in short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("Error resolving module root: %v", err)
+	}
+
+	src, err := generate("models", []string{"User", "Order"})
+	if err != nil {
+		t.Fatalf("Error generating IDs: %v", err)
+	}
+
+	dir := t.TempDir()
+	goMod := "module example.com/models\n\ngo 1.24\n\n" +
+		"require github.com/cloudresty/ulid v0.0.0\n\n" +
+		"replace github.com/cloudresty/ulid => " + root + "\n"
+	files := map[string]string{
+		"go.mod":         goMod,
+		"ulid_ids.go":    string(src),
+		"models_test.go": modelsTest,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Error writing %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command(goTool, "test", "-count=1", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Generated package does not build and test: %v\n%s", err, out)
+	}
+}
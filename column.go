@@ -0,0 +1,35 @@
+package ulid
+
+import "errors"
+
+// AppendFixedSizeBinary appends the 16-byte big-endian form of each ID to dst
+// and returns the extended buffer. The result is laid out as contiguous
+// 16-byte values, which is exactly the values buffer of an Arrow
+// FixedSizeBinary(16) array and the value encoding of a Parquet
+// FIXED_LEN_BYTE_ARRAY(16) column, so exporters can hand it to those writers
+// without per-row conversions.
+func AppendFixedSizeBinary(dst []byte, ids []ULID) []byte {
+	dst = append(dst, make([]byte, len(ids)*totalBytes)...)
+	out := dst[len(dst)-len(ids)*totalBytes:]
+	for i, id := range ids {
+		data := id.bytes()
+		copy(out[i*totalBytes:], data[:])
+	}
+	return dst
+}
+
+// DecodeFixedSizeBinary decodes a buffer of contiguous 16-byte values, such as
+// the values buffer of an Arrow FixedSizeBinary(16) array, appending the IDs
+// to dst. It returns an error if the buffer length is not a multiple of 16.
+func DecodeFixedSizeBinary(dst []ULID, data []byte) ([]ULID, error) {
+	if len(data)%totalBytes != 0 {
+		return dst, errors.New("binary column length is not a multiple of 16")
+	}
+
+	dst = append(dst, make([]ULID, len(data)/totalBytes)...)
+	out := dst[len(dst)-len(data)/totalBytes:]
+	for i := range out {
+		out[i] = fromBytes([totalBytes]byte(data[i*totalBytes:]))
+	}
+	return dst, nil
+}
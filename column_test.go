@@ -0,0 +1,42 @@
+package ulid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestFixedSizeBinaryRoundTrip(t *testing.T) {
+	ids, err := GenerateBetween(time.Now().Add(-time.Hour), time.Now(), 100)
+	if err != nil {
+		t.Fatalf("Error generating ULIDs: %v", err)
+	}
+
+	prefix := []byte{0xAA}
+	buf := AppendFixedSizeBinary(prefix, ids)
+	if len(buf) != 1+len(ids)*16 {
+		t.Fatalf("Buffer length mismatch: got %d, expected %d", len(buf), 1+len(ids)*16)
+	}
+
+	first := ids[0].bytes()
+	if !bytes.Equal(buf[1:17], first[:]) {
+		t.Errorf("First value mismatch: got %x, expected %x", buf[1:17], first)
+	}
+
+	decoded, err := DecodeFixedSizeBinary(nil, buf[1:])
+	if err != nil {
+		t.Fatalf("Error decoding column: %v", err)
+	}
+	if len(decoded) != len(ids) {
+		t.Fatalf("Decoded count mismatch: got %d, expected %d", len(decoded), len(ids))
+	}
+	for i := range ids {
+		if decoded[i] != ids[i] {
+			t.Fatalf("Value %d mismatch: got %v, expected %v", i, decoded[i], ids[i])
+		}
+	}
+
+	if _, err := DecodeFixedSizeBinary(nil, buf[:20]); err == nil {
+		t.Errorf("Expected error for truncated column")
+	}
+}
@@ -0,0 +1,20 @@
+package ulid
+
+import "cmp"
+
+// Compare returns -1, 0 or +1 depending on whether u sorts before, equal to,
+// or after other. The order is byte-lexicographic over the 16-byte binary
+// form, which matches the order of the canonical string forms.
+func (u ULID) Compare(other ULID) int {
+	if c := cmp.Compare(u.timestamp, other.timestamp); c != 0 {
+		return c
+	}
+	return compareRandomness(u.randomness, other.randomness)
+}
+
+// Compare returns -1, 0 or +1 depending on whether a sorts before, equal to,
+// or after b. It can be passed directly to slices.SortFunc and
+// slices.BinarySearchFunc.
+func Compare(a, b ULID) int {
+	return a.Compare(b)
+}
@@ -0,0 +1,39 @@
+package ulid
+
+import (
+	mathrand "math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	ids := make([]ULID, 0, 300)
+	for i := range 300 {
+		u, err := NewULIDTime(1700000000000 + uint64(i%3)*1000)
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		ids = append(ids, u)
+	}
+	mathrand.Shuffle(len(ids), func(i, j int) {
+		ids[i], ids[j] = ids[j], ids[i]
+	})
+
+	slices.SortFunc(ids, Compare)
+	for i := 1; i < len(ids); i++ {
+		if strings.Compare(ids[i-1].String(), ids[i].String()) >= 0 {
+			t.Fatalf("Compare order differs from string order at %d: %s, %s", i, ids[i-1], ids[i])
+		}
+	}
+
+	if _, found := slices.BinarySearchFunc(ids, ids[123], Compare); !found {
+		t.Errorf("Expected binary search to find an existing ULID")
+	}
+
+	a := ULID{timestamp: 1, randomness: [randomnessBytes]byte{9}}
+	b := ULID{timestamp: 2}
+	if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+		t.Errorf("Expected timestamp to dominate the comparison")
+	}
+}
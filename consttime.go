@@ -0,0 +1,33 @@
+package ulid
+
+import "crypto/subtle"
+
+// EqualConstantTime reports whether u and other are equal, in time that does
+// not depend on where they differ. Use it when ULIDs act as secrets, such as
+// password-reset tokens or signed URL identifiers.
+func (u ULID) EqualConstantTime(other ULID) bool {
+	a, b := u.bytes(), other.bytes()
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+// ConstantTimeCompareStrings reports whether two ULID strings are equal,
+// ignoring letter case, in time that depends only on their lengths. The
+// strings are not validated.
+func ConstantTimeCompareStrings(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	var diff byte
+	for i := range len(a) {
+		diff |= foldConstantTime(a[i]) ^ foldConstantTime(b[i])
+	}
+	return subtle.ConstantTimeByteEq(diff, 0) == 1
+}
+
+// foldConstantTime folds an ASCII upper-case letter to lower case without
+// branching on its value
+func foldConstantTime(c byte) byte {
+	upper := subtle.ConstantTimeLessOrEq('A', int(c)) & subtle.ConstantTimeLessOrEq(int(c), 'Z')
+	return c | byte(upper<<5)
+}
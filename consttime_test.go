@@ -0,0 +1,45 @@
+package ulid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqualConstantTime(t *testing.T) {
+	a, err := NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	b, err := a.Next()
+	if err != nil {
+		t.Fatalf("Error computing Next: %v", err)
+	}
+
+	if !a.EqualConstantTime(a) {
+		t.Errorf("Expected ULID to equal itself")
+	}
+	if a.EqualConstantTime(b) {
+		t.Errorf("Expected different ULIDs to differ")
+	}
+}
+
+func TestConstantTimeCompareStrings(t *testing.T) {
+	s := "01arz3ndektsv4rrffq69g5far"
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{s, s, true},
+		{s, strings.ToUpper(s), true},
+		{s, s[:25] + "w", false},
+		{s, s[:25], false},
+		{"0", "\x10", false},
+		{"a", "!", false},
+	}
+	for _, tt := range tests {
+		if got := ConstantTimeCompareStrings(tt.a, tt.b); got != tt.want {
+			t.Errorf("ConstantTimeCompareStrings(%q, %q) = %v, expected %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package ulid
+
+import "context"
+
+// contextKey is the unexported key type for ULIDs stored in a context
+type contextKey struct{}
+
+// ContextWith returns a copy of ctx carrying u, typically the ID of the
+// request being served.
+func ContextWith(ctx context.Context, u ULID) context.Context {
+	return context.WithValue(ctx, contextKey{}, u)
+}
+
+// FromContext returns the ULID stored in ctx by ContextWith, if any.
+func FromContext(ctx context.Context) (ULID, bool) {
+	u, ok := ctx.Value(contextKey{}).(ULID)
+	return u, ok
+}
+
+// NewContext is like New, but returns the context's error instead of an ID
+// once ctx is done. Combined with OverflowWaitForNextMillisecond this bounds
+// how long a request handler can wait for the clock to advance.
+func NewContext(ctx context.Context) (string, error) {
+	return Default().NewContext(ctx)
+}
+
+// NewContext is like New, but gives up with the context's error once ctx is
+// done, as with the package-level NewContext. A read from a blocking entropy
+// source set with WithEntropy cannot be interrupted.
+func (g *Generator) NewContext(ctx context.Context) (string, error) {
+	u, _, err := g.generateContext(ctx, 0, true, false)
+	if err != nil {
+		return "", err
+	}
+	return g.encode(&u), nil
+}
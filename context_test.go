@@ -0,0 +1,49 @@
+package ulid
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Errorf("Expected no ULID in empty context")
+	}
+
+	u := ULID{timestamp: 1678886400000}
+	ctx := ContextWith(context.Background(), u)
+
+	got, ok := FromContext(ctx)
+	if !ok || got != u {
+		t.Errorf("FromContext mismatch: got %v, %v; expected %v", got, ok, u)
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	id, err := NewContext(context.Background())
+	if err != nil || !IsValid(id) {
+		t.Fatalf("Expected a valid ULID, got %q, %v", id, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestGeneratorNewContextWaitDeadline(t *testing.T) {
+	// A stopped clock never reaches the next millisecond
+	stopped := time.Now()
+	g := exhaustedGenerator(uint64(stopped.UnixMilli()),
+		WithClock(func() time.Time { return stopped }),
+		WithOverflowPolicy(OverflowWaitForNextMillisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := g.NewContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package ulid
+
+import (
+	"math/big"
+	"time"
+)
+
+// Delta describes the difference between two ULIDs.
+type Delta struct {
+	// Duration is the difference between the embedded timestamps.
+	Duration time.Duration
+
+	// Entropy is the signed difference between the 80-bit randomness values.
+	Entropy *big.Int
+}
+
+// Distance returns the difference b - a, component by component, for gap
+// analysis in event streams. For IDs that share a millisecond, Entropy is
+// positive exactly when b was generated after a by a monotonic generator.
+func Distance(a, b ULID) Delta {
+	ea := new(big.Int).SetBytes(a.randomness[:])
+	eb := new(big.Int).SetBytes(b.randomness[:])
+	return Delta{
+		Duration: b.Sub(a),
+		Entropy:  eb.Sub(eb, ea),
+	}
+}
@@ -0,0 +1,35 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDistance(t *testing.T) {
+	timestamp := uint64(time.Now().Add(72 * time.Hour).UnixMilli())
+	first, err := NewULIDTime(timestamp)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	var last ULID
+	for range 5 {
+		if last, err = NewULIDTime(timestamp); err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+	}
+
+	d := Distance(first, last)
+	if d.Duration != 0 {
+		t.Errorf("Expected zero duration, got %v", d.Duration)
+	}
+	if d.Entropy.Sign() <= 0 {
+		t.Errorf("Expected positive entropy distance, got %s", d.Entropy)
+	}
+
+	a := ULID{timestamp: 1000, randomness: [randomnessBytes]byte{9: 10}}
+	b := ULID{timestamp: 3500, randomness: [randomnessBytes]byte{9: 4}}
+	d = Distance(a, b)
+	if d.Duration != 2500*time.Millisecond || d.Entropy.Int64() != -6 {
+		t.Errorf("Distance mismatch: got %v, %s", d.Duration, d.Entropy)
+	}
+}
@@ -0,0 +1,67 @@
+package ulid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+)
+
+// drbgReseedBytes is the amount of keystream a drbg produces before it is
+// rekeyed from crypto/rand, roughly 100,000 IDs
+const drbgReseedBytes = 1 << 20
+
+// drbg is an AES-256-CTR deterministic random bit generator seeded from
+// crypto/rand. It is not safe for concurrent use; a Generator serializes
+// reads from it like any other entropy source.
+type drbg struct {
+	stream cipher.Stream
+	left   int // keystream bytes remaining before the next reseed
+}
+
+// Read fills p with keystream, reseeding first when the current key has
+// produced drbgReseedBytes
+func (d *drbg) Read(p []byte) (int, error) {
+	if d.left < len(p) {
+		if err := d.reseed(); err != nil {
+			return 0, err
+		}
+	}
+	clear(p)
+	d.stream.XORKeyStream(p, p)
+	d.left -= len(p)
+	return len(p), nil
+}
+
+// reseed replaces the key and counter with fresh crypto/rand output
+func (d *drbg) reseed() error {
+	var seed [32 + aes.BlockSize]byte
+	defer clear(seed[:])
+	if _, err := rand.Read(seed[:]); err != nil {
+		return err
+	}
+
+	block, err := aes.NewCipher(seed[:32])
+	if err != nil {
+		return err
+	}
+	d.stream = cipher.NewCTR(block, seed[32:])
+	d.left = drbgReseedBytes
+	return nil
+}
+
+// WithFastCryptoEntropy makes the Generator draw randomness from an
+// AES-256-CTR generator in userspace, keyed from crypto/rand and rekeyed
+// after every MiB of output, instead of calling into crypto/rand for every
+// ID. AES-CTR keystream is indistinguishable from random to anyone without
+// the key, so IDs remain unpredictable while generation avoids a
+// crypto/rand call per ID or per buffer refill.
+//
+// The key of the current period is held in memory, so a memory disclosure
+// could reveal the randomness of IDs generated until the next reseed; use the
+// default source where that matters. The option replaces any source set with
+// WithEntropy.
+func WithFastCryptoEntropy() GeneratorOption {
+	return func(g *Generator) {
+		g.setEntropy(&drbg{})
+	}
+}
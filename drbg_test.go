@@ -0,0 +1,58 @@
+package ulid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWithFastCryptoEntropy(t *testing.T) {
+	g := NewGenerator(WithFastCryptoEntropy())
+	timestamp := uint64(time.Now().UnixMilli())
+
+	seen := make(map[[randomnessBytes]byte]bool)
+	for i := range 1000 {
+		u, err := g.NewULIDTime(timestamp + uint64(i))
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		if seen[u.Entropy()] {
+			t.Fatalf("Repeated entropy: %x", u.Entropy())
+		}
+		seen[u.Entropy()] = true
+	}
+}
+
+func TestDRBGReseed(t *testing.T) {
+	var d drbg
+	first := make([]byte, 32)
+	if _, err := d.Read(first); err != nil {
+		t.Fatalf("Error reading: %v", err)
+	}
+	if d.left != drbgReseedBytes-len(first) {
+		t.Errorf("Expected %d bytes left after the initial seed, got %d", drbgReseedBytes-len(first), d.left)
+	}
+
+	// Exhausting the budget forces a new key
+	stream := d.stream
+	d.left = 8
+	second := make([]byte, 32)
+	if _, err := d.Read(second); err != nil {
+		t.Fatalf("Error reading: %v", err)
+	}
+	if d.stream == stream {
+		t.Error("Expected a reseed once the keystream budget is exhausted")
+	}
+	if bytes.Equal(first, second) {
+		t.Error("Expected different output after reseeding")
+	}
+}
+
+func BenchmarkNewTimeFastCryptoEntropy(b *testing.B) {
+	g := NewGenerator(WithFastCryptoEntropy())
+	timestamp := uint64(time.Now().UnixMilli())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.NewTime(timestamp + uint64(i))
+	}
+}
@@ -0,0 +1,169 @@
+package ulid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Encoding is a base32 encoding scheme for ULIDs built on an alternative
+// 32-character alphabet. It reuses the optimized codec of the default
+// Crockford encoding, so IDs produced with a custom alphabet keep the same
+// bit layout and sort order.
+type Encoding struct {
+	encode   [32]byte
+	decode   [256]byte
+	sortable bool
+}
+
+// Predefined encoding profiles for interoperating with systems standardized
+// on other Base32 dialects.
+var (
+	// LowerCrockford is the default encoding produced by String: lower-case
+	// Crockford Base32, decoding either case and the aliases I, L, O and U.
+	LowerCrockford = mustEncoding(newEncoding("0123456789abcdefghjkmnpqrstvwxyz", true)).withCrockfordAliases()
+
+	// UpperCrockford is Crockford Base32 in upper case, as in the ULID
+	// specification, decoding like LowerCrockford.
+	UpperCrockford = mustEncoding(newEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ", true)).withCrockfordAliases()
+
+	// ZBase32 is the human-oriented z-base-32 alphabet. Its characters are
+	// not in byte order, so encoded strings do not sort by time.
+	ZBase32 = mustEncoding(newEncoding("ybndrfg8ejkmcpqxot1uwisza345h769", false))
+)
+
+// NewEncoding returns an Encoding for the given 32-character alphabet.
+//
+// The alphabet must consist of 32 distinct printable ASCII characters in
+// strictly ascending byte order, so that encoded strings sort in the same
+// order as the ULIDs they represent. Letters are decoded case-insensitively
+// unless both cases are part of the alphabet.
+func NewEncoding(alphabet string) (*Encoding, error) {
+	return newEncoding(alphabet, true)
+}
+
+// NewUnsortedEncoding is like NewEncoding but accepts an alphabet in any
+// order, for compatibility with Base32 dialects such as z-base-32. Strings
+// in such an encoding do not sort in the order of the ULIDs they represent;
+// compare the decoded ULIDs instead.
+func NewUnsortedEncoding(alphabet string) (*Encoding, error) {
+	return newEncoding(alphabet, false)
+}
+
+// newEncoding builds an Encoding, requiring ascending byte order if sorted
+// is set
+func newEncoding(alphabet string, sorted bool) (*Encoding, error) {
+	if len(alphabet) != 32 {
+		return nil, fmt.Errorf("alphabet must contain 32 characters, got %d", len(alphabet))
+	}
+
+	e := &Encoding{sortable: sorted}
+	for i := range e.decode {
+		e.decode[i] = 0xFF
+	}
+
+	for i := range len(alphabet) {
+		c := alphabet[i]
+		if c <= ' ' || c > '~' {
+			return nil, fmt.Errorf("alphabet contains non-printable or non-ASCII byte 0x%02x", c)
+		}
+		if e.decode[c] != 0xFF {
+			return nil, fmt.Errorf("alphabet contains duplicate character %q", c)
+		}
+		if sorted && i > 0 && c <= alphabet[i-1] {
+			return nil, errors.New("alphabet must be in ascending byte order to preserve sortability")
+		}
+		e.encode[i] = c
+		e.decode[c] = byte(i)
+	}
+
+	// Accept the other case of letters that are not themselves part of the alphabet
+	for i, c := range e.encode {
+		var other byte
+		switch {
+		case c >= 'a' && c <= 'z':
+			other = c - 'a' + 'A'
+		case c >= 'A' && c <= 'Z':
+			other = c - 'A' + 'a'
+		default:
+			continue
+		}
+		if e.decode[other] == 0xFF {
+			e.decode[other] = byte(i)
+		}
+	}
+
+	return e, nil
+}
+
+// mustEncoding panics if a predefined encoding is invalid
+func mustEncoding(e *Encoding, err error) *Encoding {
+	if err != nil {
+		panic("ulid: " + err.Error())
+	}
+	return e
+}
+
+// withCrockfordAliases makes e decode I and L as 1, O as 0 and U as V, in
+// either case, like Parse
+func (e *Encoding) withCrockfordAliases() *Encoding {
+	for _, alias := range [...]struct{ from, to byte }{{'i', '1'}, {'l', '1'}, {'o', '0'}, {'u', 'v'}} {
+		e.decode[alias.from] = e.decode[alias.to]
+		e.decode[lowerToUpper(alias.from)] = e.decode[alias.to]
+	}
+	return e
+}
+
+// Sortable reports whether strings in this encoding sort in the same order
+// as the ULIDs they represent.
+func (e *Encoding) Sortable() bool {
+	return e.sortable
+}
+
+// Encode returns the string representation of u in this encoding.
+func (e *Encoding) Encode(u ULID) string {
+	data := u.bytes()
+	return ultraFastEncode(&e.encode, &data)
+}
+
+// Decode parses a string produced by Encode.
+func (e *Encoding) Decode(s string) (ULID, error) {
+	data, err := ultraFastDecode(&e.decode, s)
+	if err != nil {
+		return ULID{}, err
+	}
+	return fromBytes(data), nil
+}
+
+// New returns a new ULID encoded with this encoding. It shares the monotonic
+// state of the package-level generator.
+func (e *Encoding) New() (string, error) {
+	u, _, err := Default().generate(0, true)
+	if err != nil {
+		return "", err
+	}
+	return e.Encode(u), nil
+}
+
+// NewTime returns a new ULID with the given timestamp in milliseconds,
+// encoded with this encoding.
+func (e *Encoding) NewTime(timestamp uint64) (string, error) {
+	if timestamp > maxTimestamp {
+		return "", ErrTimestampOverflow
+	}
+
+	u, _, err := Default().generate(timestamp, false)
+	if err != nil {
+		return "", err
+	}
+	return e.Encode(u), nil
+}
+
+// WithEncoding makes the string-returning methods of the Generator, such as
+// New, NewTime and NewWithSequence, encode IDs with e instead of the default
+// lower-case Crockford alphabet, e.g. UpperCrockford or ZBase32. Decode the
+// strings with e.Decode. A nil encoding restores the default.
+func WithEncoding(e *Encoding) GeneratorOption {
+	return func(g *Generator) {
+		g.encoding = e
+	}
+}
@@ -0,0 +1,134 @@
+package ulid
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestNewEncodingValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		alphabet string
+	}{
+		{"too short", "0123456789"},
+		{"duplicate", "0023456789ABCDEFGHJKMNPQRSTVWXYZ"},
+		{"unsorted", "1023456789ABCDEFGHJKMNPQRSTVWXYZ"},
+		{"non-printable", "\x01123456789ABCDEFGHJKMNPQRSTVWXYZ"},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewEncoding(tt.alphabet); err == nil {
+			t.Errorf("Expected error for %s alphabet", tt.name)
+		}
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	// Crockford upper case equals the default encoding apart from letter case
+	upper, err := NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ")
+	if err != nil {
+		t.Fatalf("Error creating encoding: %v", err)
+	}
+
+	ulidStr, err := New()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	parsed, err := Parse(ulidStr)
+	if err != nil {
+		t.Fatalf("Error parsing ULID: %v", err)
+	}
+
+	encoded := upper.Encode(parsed)
+	decoded, err := upper.Decode(encoded)
+	if err != nil {
+		t.Fatalf("Error decoding ULID: %v", err)
+	}
+	if decoded != parsed {
+		t.Errorf("Round trip mismatch: got %v, expected %v", decoded, parsed)
+	}
+
+	// Lower case input is accepted for letters not in the alphabet
+	if _, err := upper.Decode(ulidStr); err != nil {
+		t.Errorf("Expected case-insensitive decoding, got error: %v", err)
+	}
+}
+
+func TestEncodingPreservesOrder(t *testing.T) {
+	custom, err := NewEncoding("23456789abcdefghijkmnpqrstuvwxyz")
+	if err != nil {
+		t.Fatalf("Error creating encoding: %v", err)
+	}
+
+	timestamp := uint64(1678886400000)
+	ids := make([]string, 50)
+	for i := range ids {
+		ids[i], err = custom.NewTime(timestamp + uint64(i%5))
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+	}
+
+	for i := 1; i < len(ids); i++ {
+		a, _ := custom.Decode(ids[i-1])
+		b, _ := custom.Decode(ids[i])
+		if (ids[i-1] < ids[i]) != (a.String() < b.String()) {
+			t.Fatalf("Custom encoding order differs from canonical order: %s vs %s", ids[i-1], ids[i])
+		}
+	}
+
+	if !sort.StringsAreSorted([]string{custom.Encode(ULID{}), custom.Encode(ULID{timestamp: 1})}) {
+		t.Errorf("Expected encoded zero ULID to sort first")
+	}
+
+	if _, err := custom.Decode("01arz3ndektsv4rrffq69g5fav"); err == nil {
+		t.Errorf("Expected error for characters outside the custom alphabet")
+	}
+}
+
+func TestEncodingProfiles(t *testing.T) {
+	u, err := Parse("01arz3ndektsv4rrffq69g5far")
+	if err != nil {
+		t.Fatalf("Error parsing ULID: %v", err)
+	}
+
+	if got := LowerCrockford.Encode(u); got != u.String() {
+		t.Errorf("LowerCrockford mismatch: got %s, expected %s", got, u.String())
+	}
+	if got := UpperCrockford.Encode(u); got != u.UpperString() {
+		t.Errorf("UpperCrockford mismatch: got %s, expected %s", got, u.UpperString())
+	}
+
+	// Crockford profiles accept the aliases like Parse
+	if decoded, err := UpperCrockford.Decode("O1ARZ3NDEKTSV4RRFFQ69G5FAR"); err != nil || decoded != u {
+		t.Errorf("Expected aliases to decode to %s, got %s, %v", u, decoded, err)
+	}
+
+	z := ZBase32.Encode(u)
+	if decoded, err := ZBase32.Decode(z); err != nil || decoded != u {
+		t.Errorf("z-base-32 round trip failed: %q -> %s, %v", z, decoded, err)
+	}
+	if ZBase32.Sortable() || !UpperCrockford.Sortable() {
+		t.Error("Sortable mismatch for the predefined profiles")
+	}
+}
+
+func TestNewUnsortedEncoding(t *testing.T) {
+	if _, err := NewUnsortedEncoding("1023456789ABCDEFGHJKMNPQRSTVWXYZ"); err != nil {
+		t.Errorf("Error creating unsorted encoding: %v", err)
+	}
+	if _, err := NewUnsortedEncoding("0023456789ABCDEFGHJKMNPQRSTVWXYZ"); err == nil {
+		t.Error("Expected an error for a duplicate character")
+	}
+}
+
+func TestWithEncoding(t *testing.T) {
+	g := NewGenerator(WithEncoding(ZBase32))
+	s, err := g.New()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if _, err := ZBase32.Decode(s); err != nil {
+		t.Errorf("Error decoding %q with the generator's encoding: %v", s, err)
+	}
+}
@@ -0,0 +1,198 @@
+package ulid
+
+import (
+	"crypto/rand"
+	"io"
+	"sync"
+)
+
+// entropyPoolSize is the number of crypto/rand bytes fetched per refill
+const entropyPoolSize = 4096
+
+// entropyPool amortizes crypto/rand reads by fetching entropy in large blocks
+// and handing it out one randomness component at a time. Bytes are wiped as
+// they are consumed, so only entropy for IDs not yet generated stays in memory.
+type entropyPool struct {
+	mu  sync.Mutex
+	buf [entropyPoolSize]byte
+	off int // offset of the next unused byte; 0 while unfilled
+}
+
+// read takes the next randomness component from the pool, refilling it
+// from crypto/rand when exhausted
+func (p *entropyPool) read() ([randomnessBytes]byte, error) {
+	var randomness [randomnessBytes]byte
+
+	p.mu.Lock()
+	if p.off == 0 || p.off+randomnessBytes > len(p.buf) {
+		if _, err := rand.Read(p.buf[:]); err != nil {
+			p.off = 0
+			p.mu.Unlock()
+			return randomness, err
+		}
+		p.off = 0
+	}
+	chunk := p.buf[p.off : p.off+randomnessBytes]
+	copy(randomness[:], chunk)
+	clear(chunk)
+	p.off += randomnessBytes
+	p.mu.Unlock()
+
+	return randomness, nil
+}
+
+// reset wipes any unused entropy from the pool
+func (p *entropyPool) reset() {
+	p.mu.Lock()
+	clear(p.buf[:])
+	p.off = 0
+	p.mu.Unlock()
+}
+
+// MonotonicReader is implemented by entropy sources that generate increasing
+// randomness within a millisecond, such as the *MonotonicEntropy of
+// github.com/oklog/ulid. A Generator given such a source with WithEntropy
+// calls MonotonicRead with the timestamp of the ID being generated, so the
+// source's monotonic strategy (e.g. its random increment size) carries over
+// unchanged.
+type MonotonicReader interface {
+	io.Reader
+
+	// MonotonicRead fills p with randomness for the millisecond ms that is
+	// greater than the randomness of the previous call for the same ms.
+	MonotonicRead(ms uint64, p []byte) error
+}
+
+// entropySource serializes reads from a caller-supplied entropy reader, which
+// need not be safe for concurrent use
+type entropySource struct {
+	mu        sync.Mutex
+	r         io.Reader
+	monotonic MonotonicReader // r, if it implements MonotonicReader
+}
+
+// read fills a randomness component from the source for the millisecond ms
+func (s *entropySource) read(ms uint64) ([randomnessBytes]byte, error) {
+	var randomness [randomnessBytes]byte
+	var err error
+	s.mu.Lock()
+	if s.monotonic != nil {
+		err = s.monotonic.MonotonicRead(ms, randomness[:])
+	} else {
+		_, err = io.ReadFull(s.r, randomness[:])
+	}
+	s.mu.Unlock()
+	return randomness, err
+}
+
+// WithEntropy makes the Generator draw randomness from r instead of
+// crypto/rand, e.g. an HSM-backed DRBG or a recorded source in tests. Reads
+// from r are serialized, so it does not need to be safe for concurrent use.
+// A short read is reported as an error. A nil reader selects crypto/rand.
+//
+// Entropy readers configured for github.com/oklog/ulid, including its
+// ulid.Monotonic wrapper, can be passed unchanged; see MonotonicReader.
+func WithEntropy(r io.Reader) GeneratorOption {
+	return func(g *Generator) {
+		g.setEntropy(r)
+	}
+}
+
+// SetEntropy makes New and the other package-level generation functions draw
+// randomness from r, as with WithEntropy. Passing nil restores crypto/rand.
+//
+// The entropy source determines how hard IDs are to guess; use a
+// cryptographically secure reader unless predictable IDs are intended.
+func SetEntropy(r io.Reader) {
+	Default().SetEntropy(r)
+}
+
+// SetEntropy replaces the entropy source of g, as with the package-level
+// SetEntropy.
+func (g *Generator) SetEntropy(r io.Reader) {
+	g.setEntropy(r)
+}
+
+// setEntropy installs r as the entropy source, or crypto/rand when r is nil
+func (g *Generator) setEntropy(r io.Reader) {
+	if r == nil {
+		g.entropy.Store(nil)
+		return
+	}
+	monotonic, _ := r.(MonotonicReader)
+	g.entropy.Store(&entropySource{r: r, monotonic: monotonic})
+}
+
+// WithEntropyBuffering controls whether the Generator reads crypto/rand in
+// 4 KiB blocks rather than once per ID, as with SetEntropyBuffering.
+// Buffering is enabled by default.
+func WithEntropyBuffering(enabled bool) GeneratorOption {
+	return func(g *Generator) {
+		g.setEntropyBuffering(enabled)
+	}
+}
+
+// SetEntropyBuffering controls whether New and the other package-level
+// generation functions read crypto/rand in 4 KiB blocks and hand the entropy
+// out one ID at a time, instead of reading crypto/rand for every ID.
+// Buffering is enabled by default and substantially reduces the cost of
+// generation under load.
+//
+// Buffered entropy for future IDs is held in memory until used, so a memory
+// disclosure could reveal the randomness of IDs generated afterwards.
+// Disable buffering where strict forward secrecy is required; doing so wipes
+// the buffer. Buffering does not apply to a source set with SetEntropy.
+func SetEntropyBuffering(enabled bool) {
+	Default().SetEntropyBuffering(enabled)
+}
+
+// SetEntropyBuffering controls entropy buffering of g, as with the
+// package-level SetEntropyBuffering.
+func (g *Generator) SetEntropyBuffering(enabled bool) {
+	g.setEntropyBuffering(enabled)
+}
+
+// setEntropyBuffering toggles the entropy pool, wiping it when disabled
+func (g *Generator) setEntropyBuffering(enabled bool) {
+	g.unbuffered.Store(!enabled)
+	if !enabled {
+		g.pool.reset()
+	}
+}
+
+// randomness reads a randomness component for an ID at timestamp, or at the
+// current time if useClock is set, from the generator's entropy source and
+// stamps it with the generator's node ID
+func (g *Generator) randomness(timestamp uint64, useClock bool) ([randomnessBytes]byte, error) {
+	var randomness [randomnessBytes]byte
+	var err error
+	s := g.entropy.Load()
+	switch {
+	case s != nil:
+		if s.monotonic != nil && useClock {
+			timestamp = g.clockMillis()
+		}
+		randomness, err = s.read(timestamp)
+	case g.unbuffered.Load():
+		randomness, err = generateRandomness()
+	default:
+		randomness, err = g.pool.read()
+	}
+	if err != nil {
+		g.stats.entropyFailures.Add(1)
+		if g.fallback == nil || s == nil {
+			return randomness, err
+		}
+		randomness, err = g.fallback.read(err)
+		if err != nil {
+			return randomness, err
+		}
+		g.stats.fallbackReads.Add(1)
+	} else if g.fallback != nil && s != nil {
+		g.fallback.recovered()
+	}
+	if g.node != nil {
+		g.node.apply(&randomness)
+	}
+	return randomness, nil
+}
@@ -0,0 +1,179 @@
+package ulid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	mathrand "math/rand"
+	"testing"
+	"time"
+)
+
+func TestWithEntropy(t *testing.T) {
+	recorded := bytes.Repeat([]byte{0xab}, randomnessBytes)
+	g := NewGenerator(WithEntropy(bytes.NewReader(recorded)))
+
+	timestamp := uint64(time.Now().UnixMilli())
+	u, err := g.NewULIDTime(timestamp)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if got := u.Entropy(); !bytes.Equal(got[:], recorded) {
+		t.Errorf("Entropy mismatch: got %x, expected %x", got, recorded)
+	}
+
+	// The recorded source is exhausted
+	if _, err := g.NewULIDTime(timestamp + 1); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected io.EOF from an exhausted source, got %v", err)
+	}
+}
+
+func TestWithEntropyShortRead(t *testing.T) {
+	g := NewGenerator(WithEntropy(bytes.NewReader(make([]byte, randomnessBytes-1))))
+	if _, err := g.NewULID(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected io.ErrUnexpectedEOF from a short read, got %v", err)
+	}
+}
+
+func TestSetEntropy(t *testing.T) {
+	SetEntropy(bytes.NewReader(make([]byte, randomnessBytes)))
+	defer SetEntropy(nil)
+
+	u, err := NewULIDTime(uint64(time.Now().Add(48 * time.Hour).UnixMilli()))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if got := u.Entropy(); got != [randomnessBytes]byte{} {
+		t.Errorf("Expected zero entropy from the configured source, got %x", got)
+	}
+
+	SetEntropy(nil)
+	if _, err := New(); err != nil {
+		t.Errorf("Error generating ULID after restoring crypto/rand: %v", err)
+	}
+}
+
+func TestEntropyBuffering(t *testing.T) {
+	for _, buffered := range []bool{true, false} {
+		g := NewGenerator(WithEntropyBuffering(buffered))
+		timestamp := uint64(time.Now().UnixMilli())
+
+		// Span several pool refills
+		seen := make(map[[randomnessBytes]byte]bool)
+		for i := range 2 * entropyPoolSize / randomnessBytes {
+			u, err := g.NewULIDTime(timestamp + uint64(i))
+			if err != nil {
+				t.Fatalf("Error generating ULID: %v", err)
+			}
+			if seen[u.Entropy()] {
+				t.Fatalf("Repeated entropy with buffering %v: %x", buffered, u.Entropy())
+			}
+			seen[u.Entropy()] = true
+		}
+	}
+}
+
+func TestSetEntropyBufferingWipesPool(t *testing.T) {
+	g := NewGenerator()
+	if _, err := g.NewULID(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+
+	g.SetEntropyBuffering(false)
+	if g.pool.buf != [entropyPoolSize]byte{} {
+		t.Error("Expected the entropy pool to be wiped when buffering is disabled")
+	}
+	if _, err := g.NewULID(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if g.pool.buf != [entropyPoolSize]byte{} {
+		t.Error("Expected the entropy pool to stay unused while buffering is disabled")
+	}
+}
+
+func BenchmarkNewTimeBufferedEntropy(b *testing.B) {
+	benchmarkNewTimeEntropy(b, true)
+}
+
+func BenchmarkNewTimeUnbufferedEntropy(b *testing.B) {
+	benchmarkNewTimeEntropy(b, false)
+}
+
+func benchmarkNewTimeEntropy(b *testing.B, buffered bool) {
+	g := NewGenerator(WithEntropyBuffering(buffered))
+	timestamp := uint64(time.Now().UnixMilli())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.NewTime(timestamp + uint64(i))
+	}
+}
+
+// monotonicReader mimics the *MonotonicEntropy of github.com/oklog/ulid:
+// randomness for a repeated millisecond is the previous value plus a
+// random increment
+type monotonicReader struct {
+	io.Reader
+	ms    uint64
+	last  [randomnessBytes]byte
+	calls []uint64
+}
+
+func (m *monotonicReader) MonotonicRead(ms uint64, p []byte) error {
+	m.calls = append(m.calls, ms)
+	if ms == m.ms && len(m.calls) > 1 {
+		var inc [1]byte
+		if _, err := io.ReadFull(m.Reader, inc[:]); err != nil {
+			return err
+		}
+		if incrementRandomness(&m.last) {
+			return errors.New("monotonic overflow")
+		}
+		for range inc[0] {
+			incrementRandomness(&m.last)
+		}
+		copy(p, m.last[:])
+		return nil
+	}
+	if _, err := io.ReadFull(m.Reader, p); err != nil {
+		return err
+	}
+	m.ms = ms
+	copy(m.last[:], p)
+	return nil
+}
+
+func TestWithEntropyMonotonicReader(t *testing.T) {
+	source := &monotonicReader{Reader: mathrand.New(mathrand.NewSource(1))}
+	g := NewGenerator(WithEntropy(source))
+	timestamp := uint64(time.Now().UnixMilli())
+
+	previous := ULID{}
+	for range 100 {
+		u, err := g.NewULIDTime(timestamp)
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		if u.Compare(previous) <= 0 {
+			t.Fatalf("Not monotonic: %s <= %s", u, previous)
+		}
+		previous = u
+	}
+
+	for _, ms := range source.calls {
+		if ms != timestamp {
+			t.Fatalf("MonotonicRead called with %d, expected %d", ms, timestamp)
+		}
+	}
+	// The source's increments are used as they are
+	if bumps := g.Stats().MonotonicBumps; bumps != 0 {
+		t.Errorf("Expected no generator increments over a monotonic source, got %d", bumps)
+	}
+}
+
+func TestWithEntropyPlainReader(t *testing.T) {
+	// A seeded math/rand source, as commonly configured for oklog/ulid
+	g := NewGenerator(WithEntropy(mathrand.New(mathrand.NewSource(1))))
+	if _, err := g.New(); err != nil {
+		t.Errorf("Error generating ULID: %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package ulid
+
+import "time"
+
+// WithEpoch makes the Generator encode timestamps as milliseconds since epoch
+// instead of since the Unix epoch, e.g. to match an internal ID scheme that
+// counts from a company's founding date or to move the 48-bit range forward.
+// Timestamps passed to NewTime and the other explicit-time methods are still
+// Unix milliseconds and are converted; those before epoch are rejected with
+// ErrTimestampOverflow, and a wall clock reading before epoch is encoded as
+// epoch itself.
+//
+// IDs from such a generator are not comparable with standard ULIDs, and
+// ULID.Time reports their raw offset as if it counted from the Unix epoch;
+// decode them with Generator.Time or ULID.TimeFrom. WithEpoch panics if
+// epoch is before the Unix epoch.
+func WithEpoch(epoch time.Time) GeneratorOption {
+	if epoch.UnixMilli() < 0 {
+		panic("ulid: epoch before the Unix epoch")
+	}
+	return func(g *Generator) {
+		g.epoch = uint64(epoch.UnixMilli())
+	}
+}
+
+// Epoch returns the epoch g's timestamps count from, the Unix epoch unless
+// set with WithEpoch.
+func (g *Generator) Epoch() time.Time {
+	return time.UnixMilli(int64(g.epoch)).UTC()
+}
+
+// Time returns the wall-clock time embedded in u, an ID generated by g,
+// taking the generator's epoch and sub-millisecond precision into account.
+func (g *Generator) Time(u ULID) time.Time {
+	t := u.TimeFrom(g.Epoch())
+	if g.subMillisecond {
+		t = t.Add(fractionDuration(fraction(&u.randomness)))
+	}
+	return t
+}
+
+// TimeFrom returns the embedded timestamp as a UTC time.Time, interpreting
+// it as milliseconds since epoch rather than the Unix epoch. It decodes IDs
+// from a generator configured with WithEpoch.
+func (u ULID) TimeFrom(epoch time.Time) time.Time {
+	return epoch.Add(time.Duration(u.timestamp) * time.Millisecond).UTC()
+}
+
+// clockMillis reads the generator's wall clock in milliseconds since its
+// epoch
+func (g *Generator) clockMillis() uint64 {
+	return g.millis(g.now())
+}
+
+// millis converts a wall-clock reading to milliseconds since the generator's
+// epoch
+func (g *Generator) millis(now time.Time) uint64 {
+	ms := uint64(now.UnixMilli())
+	if ms < g.epoch {
+		return 0
+	}
+	return ms - g.epoch
+}
+
+// sinceEpoch converts a Unix millisecond timestamp to milliseconds since the
+// generator's epoch
+func (g *Generator) sinceEpoch(timestamp uint64) (uint64, error) {
+	if timestamp < g.epoch {
+		return 0, ErrTimestampOverflow
+	}
+	return timestamp - g.epoch, nil
+}
@@ -0,0 +1,55 @@
+package ulid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithEpoch(t *testing.T) {
+	epoch := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2024, time.June, 30, 13, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithEpoch(epoch), WithClock(func() time.Time { return now }))
+
+	u, err := g.NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if want := uint64(now.Sub(epoch).Milliseconds()); u.GetTime() != want {
+		t.Errorf("Embedded timestamp mismatch: got %d, expected %d", u.GetTime(), want)
+	}
+	if got := g.Time(u); !got.Equal(now) {
+		t.Errorf("Decoded time mismatch: got %v, expected %v", got, now)
+	}
+	if got := u.TimeFrom(epoch); !got.Equal(now) {
+		t.Errorf("TimeFrom mismatch: got %v, expected %v", got, now)
+	}
+
+	later := now.Add(time.Hour)
+	u, err = g.NewULIDTime(uint64(later.UnixMilli()))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if got := g.Time(u); !got.Equal(later) {
+		t.Errorf("Decoded explicit time mismatch: got %v, expected %v", got, later)
+	}
+
+	if _, err := g.NewTime(uint64(epoch.UnixMilli()) - 1); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Expected ErrTimestampOverflow before the epoch, got %v", err)
+	}
+}
+
+func TestGeneratorEpochDefault(t *testing.T) {
+	g := NewGenerator()
+	if !g.Epoch().Equal(time.UnixMilli(0)) {
+		t.Errorf("Expected the Unix epoch, got %v", g.Epoch())
+	}
+
+	u, err := g.NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if !g.Time(u).Equal(u.Time()) {
+		t.Errorf("Time mismatch: got %v, expected %v", g.Time(u), u.Time())
+	}
+}
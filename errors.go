@@ -0,0 +1,126 @@
+package ulid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalidLength matches every *LengthError via errors.Is.
+	ErrInvalidLength = errors.New("invalid ULID length")
+
+	// ErrInvalidCharacter matches every *InvalidCharacterError via errors.Is.
+	ErrInvalidCharacter = errors.New("invalid character in ULID")
+
+	// ErrOverflow is returned by strict parsing when a string encodes bits
+	// beyond the 128 bits of a ULID.
+	ErrOverflow = errors.New("ULID string overflows 128 bits")
+
+	// ErrChecksum is returned by ParseChecked when the checksum suffix does
+	// not match the ULID.
+	ErrChecksum = errors.New("ULID checksum mismatch")
+
+	// ErrTimestampOverflow is returned when a timestamp does not fit in 48 bits.
+	ErrTimestampOverflow = errors.New("timestamp out of range")
+
+	// ErrTimestampRejected is returned by ParseWithOptions when the embedded
+	// timestamp falls outside the configured window.
+	ErrTimestampRejected = errors.New("ULID timestamp outside the accepted window")
+
+	// ErrEntropyExhausted is returned when every randomness value of the
+	// maximum timestamp has been used, so monotonic generation cannot
+	// continue, or when OverflowWaitForNextMillisecond would have to wait for
+	// a clock that is behind the exhausted millisecond.
+	ErrEntropyExhausted = errors.New("timestamp out of range due to randomness exhaustion")
+
+	// ErrMonotonicOverflow is returned by a Generator whose overflow policy
+	// does not allow moving past an exhausted millisecond.
+	ErrMonotonicOverflow = errors.New("monotonic randomness exhausted within the millisecond")
+
+	// ErrClockRegression is returned by a Generator with the
+	// ClockRegressionError policy while the wall clock is behind the last
+	// millisecond it reported.
+	ErrClockRegression = errors.New("wall clock moved backwards")
+
+	// ErrRateLimited matches every *RateLimitError via errors.Is.
+	ErrRateLimited = errors.New("ULID generation rate limit exceeded")
+)
+
+// InvalidCharacterError reports a character outside the encoding alphabet.
+type InvalidCharacterError struct {
+	// Pos is the byte offset of the character in the input.
+	Pos int
+
+	// Char is the offending byte.
+	Char byte
+}
+
+// Error implements the error interface.
+func (e *InvalidCharacterError) Error() string {
+	return fmt.Sprintf("invalid character %q at position %d in ULID", e.Char, e.Pos)
+}
+
+// Is reports whether target is ErrInvalidCharacter.
+func (e *InvalidCharacterError) Is(target error) bool {
+	return target == ErrInvalidCharacter
+}
+
+// LengthError reports input of the wrong length.
+type LengthError struct {
+	// Expected is the required length.
+	Expected int
+
+	// Actual is the length of the input.
+	Actual int
+}
+
+// Error implements the error interface.
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("invalid ULID length: expected %d, got %d", e.Expected, e.Actual)
+}
+
+// Is reports whether target is ErrInvalidLength.
+func (e *LengthError) Is(target error) bool {
+	return target == ErrInvalidLength
+}
+
+// IndexError reports the failure of one entry in a bulk operation.
+type IndexError struct {
+	// Index is the position of the failing entry in the input.
+	Index int
+
+	// Err is the error for that entry.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("entry %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error for the entry.
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// RateLimitError reports that a rate-limited Generator refused to issue an ID.
+type RateLimitError struct {
+	// RetryAfter is how long until the next ID can be issued.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("ULID generation rate limit exceeded, retry after %v", e.RetryAfter)
+}
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// lengthError returns a *LengthError as an error
+func lengthError(want, got int) error {
+	return &LengthError{Expected: want, Actual: got}
+}
@@ -0,0 +1,51 @@
+package ulid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse("01arz3ndektsv4rrffq69g5fa")
+	if !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Expected ErrInvalidLength, got %v", err)
+	}
+	var lenErr *LengthError
+	if !errors.As(err, &lenErr) {
+		t.Fatalf("Expected *LengthError, got %T", err)
+	}
+	if lenErr.Expected != 26 || lenErr.Actual != 25 {
+		t.Errorf("Unexpected error details: %+v", lenErr)
+	}
+	if err.Error() != "invalid ULID length: expected 26, got 25" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	_, err = Parse("01arz3ndek!sv4rrffq69g5far")
+	if !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("Expected ErrInvalidCharacter, got %v", err)
+	}
+	var charErr *InvalidCharacterError
+	if !errors.As(err, &charErr) {
+		t.Fatalf("Expected *InvalidCharacterError, got %T", err)
+	}
+	if charErr.Pos != 10 || charErr.Char != '!' {
+		t.Errorf("Unexpected error details: %+v", charErr)
+	}
+	if err.Error() != `invalid character '!' at position 10 in ULID` {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	if _, err := FromBytes(make([]byte, 3)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Expected ErrInvalidLength, got %v", err)
+	}
+}
+
+func TestGenerationErrors(t *testing.T) {
+	if _, err := NewTime(maxTimestamp + 1); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Expected ErrTimestampOverflow, got %v", err)
+	}
+	if _, err := Max.Add(1e6); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Expected ErrTimestampOverflow, got %v", err)
+	}
+}
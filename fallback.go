@@ -0,0 +1,57 @@
+package ulid
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// fallbackEntropy is a secondary entropy source used while the primary fails
+type fallbackEntropy struct {
+	source     entropySource
+	onDegraded func(err error)
+	degraded   atomic.Bool
+}
+
+// WithFallbackEntropy makes the Generator draw randomness from fallback
+// whenever the reader set with WithEntropy (or SetEntropy) fails or returns a
+// short read, instead of failing generation while, e.g., a remote HSM or
+// DRBG is unavailable. Reads from fallback are serialized.
+//
+// The fallback never applies to the default crypto/rand source: since Go
+// 1.24 crypto/rand.Read does not return errors and crashes the program if
+// the system source fails, so there is no failure to recover from.
+//
+// onDegraded, if not nil, is called with the primary's error when the
+// Generator switches to the fallback, and with nil once the primary works
+// again; it is not called for every failed read. The primary is retried for
+// every ID. GeneratorStats counts primary failures in EntropyFailures and
+// successful fallback reads in FallbackReads.
+//
+// IDs are only as unpredictable as the fallback source, so it should be a
+// cryptographically secure generator seeded independently of the primary.
+func WithFallbackEntropy(fallback io.Reader, onDegraded func(err error)) GeneratorOption {
+	return func(g *Generator) {
+		g.fallback = &fallbackEntropy{source: entropySource{r: fallback}, onDegraded: onDegraded}
+	}
+}
+
+// read substitutes fallback randomness after the primary failed with cause
+func (f *fallbackEntropy) read(cause error) ([randomnessBytes]byte, error) {
+	if f.degraded.CompareAndSwap(false, true) && f.onDegraded != nil {
+		f.onDegraded(cause)
+	}
+
+	randomness, err := f.source.read(0)
+	if err != nil {
+		return randomness, errors.Join(cause, err)
+	}
+	return randomness, nil
+}
+
+// recovered records a successful read from the primary source
+func (f *fallbackEntropy) recovered() {
+	if f.degraded.Load() && f.degraded.CompareAndSwap(true, false) && f.onDegraded != nil {
+		f.onDegraded(nil)
+	}
+}
@@ -0,0 +1,80 @@
+package ulid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// flakyReader fails while broken is set and reads zeros otherwise
+type flakyReader struct {
+	broken bool
+}
+
+var errFlaky = errors.New("entropy source unavailable")
+
+func (r *flakyReader) Read(p []byte) (int, error) {
+	if r.broken {
+		return 0, errFlaky
+	}
+	clear(p)
+	return len(p), nil
+}
+
+func TestWithFallbackEntropy(t *testing.T) {
+	primary := &flakyReader{broken: true}
+	fallback := bytes.NewReader(bytes.Repeat([]byte{0xfe}, 2*randomnessBytes))
+
+	var events []error
+	g := NewGenerator(WithEntropy(primary), WithFallbackEntropy(fallback, func(err error) {
+		events = append(events, err)
+	}))
+
+	for range 2 {
+		u, err := g.NewULID()
+		if err != nil {
+			t.Fatalf("Expected the fallback to serve the read, got %v", err)
+		}
+		if u.randomness[0] != 0xfe {
+			t.Errorf("Expected fallback randomness, got %x", u.randomness)
+		}
+	}
+	if len(events) != 1 || !errors.Is(events[0], errFlaky) {
+		t.Fatalf("Expected one degradation event, got %v", events)
+	}
+
+	primary.broken = false
+	if _, err := g.NewULID(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if len(events) != 2 || events[1] != nil {
+		t.Errorf("Expected a recovery event, got %v", events)
+	}
+
+	stats := g.Stats()
+	if stats.EntropyFailures != 2 || stats.FallbackReads != 2 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
+
+func TestWithFallbackEntropyExhausted(t *testing.T) {
+	g := NewGenerator(WithEntropy(&flakyReader{broken: true}), WithFallbackEntropy(bytes.NewReader(nil), nil))
+
+	_, err := g.New()
+	if !errors.Is(err, errFlaky) || !errors.Is(err, io.EOF) {
+		t.Errorf("Expected both the primary and fallback errors, got %v", err)
+	}
+}
+
+func TestWithFallbackEntropyDefaultSource(t *testing.T) {
+	g := NewGenerator(WithFallbackEntropy(bytes.NewReader(nil), func(err error) {
+		t.Errorf("Unexpected degradation event: %v", err)
+	}))
+	if _, err := g.New(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if got := g.Stats().FallbackReads; got != 0 {
+		t.Errorf("Expected no fallback reads with crypto/rand, got %d", got)
+	}
+}
@@ -0,0 +1,77 @@
+package ulid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Fields is a fully decoded view of a ULID, intended for error reports,
+// debugging output and admin interfaces.
+type Fields struct {
+	// ULID is the canonical string representation.
+	ULID string
+
+	// Milliseconds is the embedded Unix timestamp in milliseconds.
+	Milliseconds uint64
+
+	// Time is the embedded timestamp as a UTC time.
+	Time time.Time
+
+	// Entropy is the 80-bit randomness component in lowercase hex.
+	Entropy string
+
+	// UUID is the 128-bit value in the 8-4-4-4-12 UUID text form.
+	UUID string
+}
+
+// Fields decomposes the ULID into its components.
+func (u ULID) Fields() Fields {
+	return Fields{
+		ULID:         u.String(),
+		Milliseconds: u.timestamp,
+		Time:         u.Time(),
+		Entropy:      hex.EncodeToString(u.randomness[:]),
+		UUID:         formatUUID(u.bytes()),
+	}
+}
+
+// Entropy returns the 80-bit randomness component.
+func (u ULID) Entropy() [randomnessBytes]byte {
+	return u.randomness
+}
+
+// EntropyUint80 returns the randomness component split into its high 16 bits
+// and low 64 bits, convenient for sharding and sampling decisions.
+func (u ULID) EntropyUint80() (hi uint16, lo uint64) {
+	v := uint80FromBytes(u.randomness)
+	return uint16(v.hi), v.lo
+}
+
+// DebugString returns a multi-line description of the ULID and its components.
+func (u ULID) DebugString() string {
+	f := u.Fields()
+	return fmt.Sprintf("ULID:      %s\nTimestamp: %d (%s)\nEntropy:   %s\nUUID:      %s",
+		f.ULID, f.Milliseconds, f.Time.Format(time.RFC3339Nano), f.Entropy, f.UUID)
+}
+
+// GoString implements fmt.GoStringer, so that %#v prints the ULID as a
+// ulid.MustParse expression instead of its unexported fields.
+func (u ULID) GoString() string {
+	return `ulid.MustParse("` + u.String() + `")`
+}
+
+// formatUUID renders 16 bytes in the 8-4-4-4-12 hex UUID form
+func formatUUID(data [totalBytes]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], data[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], data[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], data[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], data[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], data[10:16])
+	return string(buf[:])
+}
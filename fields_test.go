@@ -0,0 +1,67 @@
+package ulid
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFields(t *testing.T) {
+	u := ULID{
+		timestamp:  1678886400000,
+		randomness: [randomnessBytes]byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef, 0x01, 0x23},
+	}
+
+	f := u.Fields()
+
+	if f.ULID != u.String() {
+		t.Errorf("ULID mismatch: got %s, expected %s", f.ULID, u.String())
+	}
+	if f.Milliseconds != 1678886400000 {
+		t.Errorf("Milliseconds mismatch: got %d", f.Milliseconds)
+	}
+	if !f.Time.Equal(time.Date(2023, 3, 15, 13, 20, 0, 0, time.UTC)) || f.Time.Location() != time.UTC {
+		t.Errorf("Time mismatch: got %v", f.Time)
+	}
+	if f.Entropy != "0123456789abcdef0123" {
+		t.Errorf("Entropy mismatch: got %s", f.Entropy)
+	}
+	if f.UUID != "0186e56d-7000-0123-4567-89abcdef0123" {
+		t.Errorf("UUID mismatch: got %s", f.UUID)
+	}
+
+	debug := u.DebugString()
+	for _, want := range []string{f.ULID, f.Entropy, f.UUID, "2023-03-15T13:20:00Z"} {
+		if !strings.Contains(debug, want) {
+			t.Errorf("DebugString missing %q:\n%s", want, debug)
+		}
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	u := ULID{timestamp: 1, randomness: [randomnessBytes]byte{0xab, 0xcd, 1, 2, 3, 4, 5, 6, 7, 8}}
+
+	if got := u.Entropy(); got != u.randomness {
+		t.Errorf("Entropy mismatch: got %x", got)
+	}
+
+	hi, lo := u.EntropyUint80()
+	if hi != 0xabcd || lo != 0x0102030405060708 {
+		t.Errorf("EntropyUint80 mismatch: got %#x %#x", hi, lo)
+	}
+}
+
+func TestGoString(t *testing.T) {
+	u := MustParse("01arz3ndektsv4rrffq69g5far")
+
+	want := `ulid.MustParse("01arz3ndektsv4rrffq69g5far")`
+	if got := fmt.Sprintf("%#v", u); got != want {
+		t.Errorf("GoString mismatch: got %s, expected %s", got, want)
+	}
+
+	type record struct{ ID ULID }
+	if got := fmt.Sprintf("%#v", record{ID: u}); !strings.Contains(got, want) {
+		t.Errorf("Expected nested GoString, got %s", got)
+	}
+}
@@ -0,0 +1,154 @@
+package ulid
+
+import (
+	"encoding/base64"
+	"errors"
+)
+
+// Format identifies the textual format of an ID.
+type Format int
+
+const (
+	// FormatUnknown is returned for strings matching none of the known formats.
+	FormatUnknown Format = iota
+
+	// FormatULID is a 26-character Crockford Base32 ULID.
+	FormatULID
+
+	// FormatUUID is a UUID in 8-4-4-4-12 hex form, optionally in braces.
+	FormatUUID
+
+	// FormatKSUID is a 27-character base62 KSUID.
+	FormatKSUID
+
+	// FormatXID is a 20-character base32hex XID.
+	FormatXID
+
+	// FormatHex128 is a 128-bit value as 32 hex digits.
+	FormatHex128
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatULID:
+		return "ULID"
+	case FormatUUID:
+		return "UUID"
+	case FormatKSUID:
+		return "KSUID"
+	case FormatXID:
+		return "XID"
+	case FormatHex128:
+		return "Hex128"
+	default:
+		return "Unknown"
+	}
+}
+
+// DetectFormat classifies s by length and alphabet. It checks the syntax of
+// each format only; for example, a string classified as FormatKSUID may
+// still exceed the KSUID value range.
+func DetectFormat(s string) Format {
+	switch len(s) {
+	case encodedLength:
+		if IsValid(s) {
+			return FormatULID
+		}
+	case 36:
+		if isUUID(s) {
+			return FormatUUID
+		}
+	case 38:
+		if s[0] == '{' && s[37] == '}' && isUUID(s[1:37]) {
+			return FormatUUID
+		}
+	case 27:
+		if allBytes(s, isBase62) {
+			return FormatKSUID
+		}
+	case 20:
+		if allBytes(s, isBase32Hex) {
+			return FormatXID
+		}
+	case 32:
+		if allBytes(s, isHex) {
+			return FormatHex128
+		}
+	}
+	return FormatUnknown
+}
+
+// isUUID reports whether s is in 8-4-4-4-12 hex form
+func isUUID(s string) bool {
+	for i := range len(s) {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHex(s[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// allBytes reports whether every byte of s satisfies f
+func allBytes(s string, f func(byte) bool) bool {
+	for i := range len(s) {
+		if !f(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func isBase62(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// isBase32Hex reports whether c is in the lower-case base32hex alphabet used by XID
+func isBase32Hex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'v'
+}
+
+// ParseAny parses an ID in any of the 128-bit formats in common use,
+// detected by length:
+//
+//   - 26 characters: a ULID string, parsed with Parse
+//   - 36 characters: a UUID in 8-4-4-4-12 form
+//   - 38 characters: a UUID in 8-4-4-4-12 form wrapped in braces
+//   - 32 characters: 128 bits in hex
+//   - 22 characters: 128 bits in unpadded base64, URL-safe or standard
+//
+// It is meant for ingestion paths that receive IDs from several producers.
+func ParseAny(s string) (ULID, error) {
+	switch len(s) {
+	case encodedLength:
+		return Parse(s)
+	case 36, 32:
+		return FromUUID(s)
+	case 38:
+		if s[0] != '{' || s[37] != '}' {
+			return ULID{}, errors.New("invalid braced UUID")
+		}
+		return FromUUID(s[1:37])
+	case 22:
+		var data [totalBytes]byte
+		if _, err := base64.RawURLEncoding.Decode(data[:], []byte(s)); err != nil {
+			if _, err := base64.RawStdEncoding.Decode(data[:], []byte(s)); err != nil {
+				return ULID{}, errors.New("invalid base64 ID")
+			}
+		}
+		return fromBytes(data), nil
+	default:
+		return ULID{}, errors.New("unrecognized ID format")
+	}
+}
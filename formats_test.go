@@ -0,0 +1,60 @@
+package ulid
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestParseAny(t *testing.T) {
+	u := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{0xfb, 0xff, 3, 4, 5, 6, 7, 8, 9, 10}}
+	data := u.Bytes()
+
+	inputs := []string{
+		u.String(),
+		strings.ToUpper(u.String()),
+		u.UUIDString(),
+		"{" + u.UUIDString() + "}",
+		hex.EncodeToString(data[:]),
+		base64.RawURLEncoding.EncodeToString(data[:]),
+		base64.RawStdEncoding.EncodeToString(data[:]),
+	}
+	for _, s := range inputs {
+		got, err := ParseAny(s)
+		if err != nil {
+			t.Fatalf("Error parsing %q: %v", s, err)
+		}
+		if got != u {
+			t.Errorf("ParseAny(%q) = %s, expected %s", s, got, u)
+		}
+	}
+
+	for _, s := range []string{"", "short", "(" + u.UUIDString() + ")", strings.Repeat("!", 22)} {
+		if _, err := ParseAny(s); err == nil {
+			t.Errorf("Expected error for %q", s)
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := map[string]Format{
+		"01arz3ndektsv4rrffq69g5far":             FormatULID,
+		"01ARZ3NDEKTSV4RRFFQ69G5FAV":             FormatULID,
+		"0186e56d-7000-0102-0304-05060708090a":   FormatUUID,
+		"{0186E56D-7000-0102-0304-05060708090A}": FormatUUID,
+		"0ujtsYcgvSTl8PAuAdqWYSMnLOv":            FormatKSUID,
+		"9m4e2mr0ui3e8a215n4g":                   FormatXID,
+		"0186e56d70000102030405060708090a":       FormatHex128,
+		"":                                       FormatUnknown,
+		"01arz3ndektsv4rrffq69g5fa!":             FormatUnknown,
+		"0186e56d-7000-0102-0304_05060708090a":   FormatUnknown,
+		"9m4e2mr0ui3e8a215n4z":                   FormatUnknown,
+		"0186e56d70000102030405060708090g":       FormatUnknown,
+	}
+	for s, want := range tests {
+		if got := DetectFormat(s); got != want {
+			t.Errorf("DetectFormat(%q) = %v, expected %v", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package ulid
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Generator produces monotonic ULIDs from its own state: the last timestamp
+// and randomness, statistics and configuration are isolated from every other
+// Generator, and generators do not contend for a shared lock. The package-level
+// functions such as New and NewTime use a default Generator.
+//
+// The common case, an ID in a new millisecond, commits the generator state
+// with a single compare-and-swap and takes no lock. IDs that share the
+// previous ID's millisecond are serialized by a mutex to increment the
+// previous randomness.
+//
+// A Generator is safe for concurrent use.
+type Generator struct {
+	// Monotonicity state, replaced as a whole on every ID; lastRandomness is
+	// masked with stateMask
+	state atomic.Pointer[generatorState]
+
+	// Serializes same-millisecond generation
+	mu sync.Mutex
+
+	// Generation statistics
+	stats generatorCounters
+
+	// Wall-clock source; nil means timeNow
+	clock func() time.Time
+
+	// Wall-clock regression hook and handling
+	clockBackwardsHook atomic.Pointer[func(previous, current uint64)]
+	clockPolicy        ClockRegressionPolicy
+
+	// Unix millisecond timestamps are encoded relative to; zero by default
+	epoch uint64
+
+	// Maximum timestamp jitter in milliseconds
+	timestampJitter atomic.Uint64
+
+	// Handling of randomness overflow within a millisecond
+	overflowPolicy OverflowPolicy
+
+	// Node ID reserved in the top randomness bits; nil when unset
+	node *nodeStamp
+
+	// Sub-millisecond fraction stored in the top randomness bits
+	subMillisecond bool
+
+	// Persisted high-water mark; nil when unset
+	persistence *persistence
+
+	// Issuance rate limit; nil when unset
+	limiter *rateLimiter
+
+	// String encoding; nil means the default set with SetUppercase
+	encoding *Encoding
+
+	// Instrumentation hooks; nil when unset
+	hooks Hooks
+
+	// Zeroization: wipe intermediate randomness and store the last
+	// randomness XOR-masked with stateMask, which is zero when disabled
+	zeroize   bool
+	stateMask [randomnessBytes]byte
+
+	// Entropy source; nil means crypto/rand, buffered through pool unless
+	// unbuffered is set
+	entropy    atomic.Pointer[entropySource]
+	pool       entropyPool
+	unbuffered atomic.Bool
+
+	// Secondary entropy source used while the primary fails; nil when unset
+	fallback *fallbackEntropy
+}
+
+// generatorState is an immutable snapshot of a Generator's monotonicity state
+type generatorState struct {
+	lastTime       uint64
+	lastRandomness [randomnessBytes]byte
+
+	// Number of IDs generated in lastTime
+	burst uint64
+
+	// Last wall-clock millisecond observed, for regression detection
+	lastClockTime uint64
+
+	// Last effective clock millisecond and the wall-clock reading it is
+	// derived from, under ClockRegressionMonotonic
+	clockEffective uint64
+	clockAnchor    time.Time
+}
+
+// GeneratorOption configures a Generator created by NewGenerator.
+type GeneratorOption func(*Generator)
+
+// WithClock sets the wall-clock source used by New, e.g. for simulations or
+// tests. The default is time.Now.
+func WithClock(now func() time.Time) GeneratorOption {
+	return func(g *Generator) {
+		g.clock = now
+	}
+}
+
+// WithClockBackwardsHook registers a hook as with OnClockBackwards.
+func WithClockBackwardsHook(fn func(previous, current uint64)) GeneratorOption {
+	return func(g *Generator) {
+		g.OnClockBackwards(fn)
+	}
+}
+
+// WithTimestampJitter enables timestamp jitter as with SetTimestampJitter.
+func WithTimestampJitter(window time.Duration) GeneratorOption {
+	return func(g *Generator) {
+		g.SetTimestampJitter(window)
+	}
+}
+
+// defaultGenerator backs the package-level generation functions
+var defaultGenerator atomic.Pointer[Generator]
+
+func init() {
+	defaultGenerator.Store(NewGenerator())
+}
+
+// Default returns the Generator used by the package-level functions such as
+// New, NewTime and Stats.
+func Default() *Generator {
+	return defaultGenerator.Load()
+}
+
+// SetDefault makes g the Generator used by the package-level functions, so
+// that entropy, clock and overflow policy can be configured once at startup
+// without passing a Generator to every call site. Package-level settings such
+// as SetEntropy apply to the current default Generator and are not carried
+// over. SetDefault panics if g is nil.
+func SetDefault(g *Generator) {
+	if g == nil {
+		panic("ulid: nil default generator")
+	}
+	defaultGenerator.Store(g)
+}
+
+// NewGenerator returns a Generator with fresh monotonic state.
+func NewGenerator(opts ...GeneratorOption) *Generator {
+	g := &Generator{}
+	g.state.Store(&generatorState{})
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.subMillisecond && g.node != nil {
+		panic("ulid: WithSubMillisecondPrecision cannot be combined with WithNode")
+	}
+	return g
+}
+
+// now reads the generator's wall clock
+func (g *Generator) now() time.Time {
+	if g.clock != nil {
+		return g.clock()
+	}
+	return timeNow()
+}
+
+// clockTimestamp reads the timestamp the next clock-based ID would get, in
+// milliseconds since the generator's epoch, without committing any state.
+// Under ClockRegressionMonotonic it never precedes the last clock reading.
+func (g *Generator) clockTimestamp() uint64 {
+	now := g.now()
+	if g.clockPolicy != ClockRegressionMonotonic {
+		return g.millis(now)
+	}
+	prev := g.state.Load()
+	now, _ = monotonicClock(prev.clockAnchor, now)
+	return max(g.millis(now), prev.clockEffective)
+}
+
+// New returns a new ULID string using the current time.
+func (g *Generator) New() (string, error) {
+	u, _, err := g.generate(0, true)
+	if err != nil {
+		return "", err
+	}
+	return g.encode(&u), nil
+}
+
+// NewTime returns a new ULID string with the given timestamp in milliseconds.
+func (g *Generator) NewTime(timestamp uint64) (string, error) {
+	u, err := g.NewULIDTime(timestamp)
+	if err != nil {
+		return "", err
+	}
+	return g.encode(&u), nil
+}
+
+// NewULID returns a new ULID struct using the current time.
+func (g *Generator) NewULID() (ULID, error) {
+	u, _, err := g.generate(0, true)
+	return u, err
+}
+
+// NewULIDTime returns a new ULID struct with the given timestamp in milliseconds.
+func (g *Generator) NewULIDTime(timestamp uint64) (ULID, error) {
+	if timestamp > maxTimestamp {
+		return ULID{}, ErrTimestampOverflow
+	}
+
+	u, _, err := g.generate(timestamp, false)
+	return u, err
+}
+
+// generate produces a monotonic ULID together with its sequence number within
+// its millisecond. When useClock is set the timestamp is read from the wall
+// clock on every attempt to commit the state, so that clock regressions can be
+// detected reliably.
+func (g *Generator) generate(timestamp uint64, useClock bool) (ULID, uint64, error) {
+	return g.generateContext(context.Background(), timestamp, useClock, false)
+}
+
+// generateContext is like generate, but gives up with the context's error
+// once ctx is done instead of waiting for the next millisecond. If
+// nonblocking is set it returns errWouldBlock instead of waiting for the
+// lock, the clock or the rate limit.
+func (g *Generator) generateContext(ctx context.Context, timestamp uint64, useClock, nonblocking bool) (ULID, uint64, error) {
+	if err := ctx.Err(); err != nil {
+		return ULID{}, 0, err
+	}
+	if !useClock && g.epoch != 0 {
+		var err error
+		if timestamp, err = g.sinceEpoch(timestamp); err != nil {
+			return ULID{}, 0, err
+		}
+	}
+	if g.persistence != nil {
+		if err := g.persistence.load(g); err != nil {
+			return ULID{}, 0, err
+		}
+	}
+	if g.limiter != nil {
+		var err error
+		if nonblocking {
+			err = g.limiter.take(g.now())
+		} else {
+			err = g.limiter.wait(ctx, g.now())
+		}
+		if err != nil {
+			return ULID{}, 0, err
+		}
+	}
+	return g.issue(ctx, timestamp, useClock, nonblocking)
+}
+
+// issue generates and commits the next ID once admitted by generateContext
+func (g *Generator) issue(ctx context.Context, timestamp uint64, useClock, nonblocking bool) (ULID, uint64, error) {
+	randomness, err := g.randomness(timestamp, useClock)
+	if err != nil {
+		if g.hooks != nil {
+			g.hooks.OnEntropyError(err)
+		}
+		return ULID{}, 0, err
+	}
+	g.stats.entropyRefills.Add(1)
+	if g.subMillisecond && !useClock {
+		setFraction(&randomness, 0)
+	}
+
+	locked := false
+	for {
+		prev := g.state.Load()
+
+		clockTime := prev.lastClockTime
+		effective, anchor := prev.clockEffective, prev.clockAnchor
+		if useClock {
+			now := g.now()
+			clockTime = g.millis(now)
+			effective = clockTime
+			if g.clockPolicy == ClockRegressionMonotonic {
+				now, anchor = monotonicClock(anchor, now)
+				effective = max(g.millis(now), prev.clockEffective)
+			}
+			timestamp = effective
+			if g.subMillisecond {
+				setFraction(&randomness, clockFraction(now))
+			}
+			if window := g.timestampJitter.Load(); window > 0 {
+				timestamp = jitterTimestamp(timestamp, window)
+			}
+			if timestamp > maxTimestamp {
+				if locked {
+					g.mu.Unlock()
+				}
+				return ULID{}, 0, ErrTimestampOverflow
+			}
+			if clockTime < prev.lastClockTime && g.clockPolicy == ClockRegressionError {
+				if locked {
+					g.mu.Unlock()
+				}
+				g.clockRegressed(prev.lastClockTime, clockTime)
+				return ULID{}, 0, ErrClockRegression
+			}
+			if g.persistence != nil || g.clockPolicy == ClockRegressionClamp {
+				// Hold the timestamp until the clock catches up
+				timestamp = max(timestamp, prev.lastTime)
+			}
+		}
+
+		// Same-millisecond IDs depend on the previous randomness; serialize
+		// them so they do not spin on the compare-and-swap
+		if timestamp == prev.lastTime && !locked {
+			if !g.mu.TryLock() {
+				if nonblocking {
+					return ULID{}, 0, errWouldBlock
+				}
+				start := time.Now()
+				g.mu.Lock()
+				g.stats.lockWait.Add(uint64(time.Since(start)))
+			}
+			locked = true
+			continue
+		}
+
+		next, events, wait, err := g.advance(prev, timestamp, randomness, useClock)
+		if wait || err != nil {
+			if locked {
+				g.mu.Unlock()
+			}
+			if g.hooks != nil {
+				g.notify(timestamp, events, err)
+			}
+			if !wait {
+				return ULID{}, 0, err
+			}
+			if nonblocking {
+				return ULID{}, 0, errWouldBlock
+			}
+			if err := g.waitPast(ctx, timestamp); err != nil {
+				return ULID{}, 0, err
+			}
+			return g.issue(ctx, 0, true, nonblocking)
+		}
+		next.lastClockTime = clockTime
+		next.clockEffective, next.clockAnchor = effective, anchor
+		u := ULID{timestamp: next.lastTime, randomness: next.lastRandomness}
+		next.lastRandomness = g.maskRandomness(next.lastRandomness)
+
+		if !g.state.CompareAndSwap(prev, next) {
+			continue
+		}
+		if locked {
+			g.mu.Unlock()
+		}
+
+		if g.persistence != nil {
+			if err := g.persistence.reserve(next.lastTime); err != nil {
+				return ULID{}, 0, err
+			}
+		}
+
+		g.stats.record(next.burst, events)
+		if useClock {
+			g.stats.clockSkew.Store(int64(effective-clockTime) * int64(time.Millisecond))
+			if clockTime < prev.lastClockTime {
+				g.clockRegressed(prev.lastClockTime, clockTime)
+			}
+		}
+
+		if g.hooks != nil {
+			g.notify(timestamp, events, nil)
+			g.hooks.OnGenerate(u)
+		}
+		if g.zeroize {
+			clear(randomness[:])
+		}
+		return u, next.burst - 1, nil
+	}
+}
+
+// advance computes the state following prev for an ID at timestamp with the
+// given fresh randomness. Same-millisecond IDs increment the previous
+// randomness when the fresh value would not sort after it. When wait is set
+// the caller must release the lock, wait for the clock to pass timestamp and
+// generate again.
+func (g *Generator) advance(prev *generatorState, timestamp uint64, randomness [randomnessBytes]byte, useClock bool) (*generatorState, generatorEvents, bool, error) {
+	if timestamp != prev.lastTime {
+		return &generatorState{lastTime: timestamp, lastRandomness: randomness, burst: 1}, 0, false, nil
+	}
+
+	last := g.maskRandomness(prev.lastRandomness)
+	next := &generatorState{lastTime: timestamp, lastRandomness: randomness, burst: prev.burst + 1}
+	if compareRandomness(randomness, last) > 0 {
+		return next, 0, false, nil
+	}
+
+	events := eventMonotonicBump
+	next.lastRandomness = last
+	if incrementRandomness(&next.lastRandomness) || (g.node != nil && !g.node.matches(&next.lastRandomness)) {
+		events |= eventOverflow
+		var wait bool
+		var err error
+		next.lastTime, wait, err = g.overflow(timestamp, useClock)
+		if wait || err != nil {
+			g.stats.overflows.Add(1)
+			return nil, events, wait, err
+		}
+
+		next.lastRandomness, err = g.randomness(next.lastTime, false)
+		if err != nil {
+			g.stats.overflows.Add(1)
+			return nil, events | eventEntropyFailure, false, err
+		}
+		if g.subMillisecond {
+			// A bumped ID starts its millisecond; fresh randomness in the
+			// same millisecond keeps the time of the exhausted fraction
+			var frac uint16
+			if next.lastTime == timestamp {
+				frac = fraction(&last)
+			}
+			setFraction(&next.lastRandomness, frac)
+		}
+		if next.lastTime != timestamp {
+			next.burst = 1
+		}
+		events |= eventEntropyRefill
+	}
+	return next, events, false, nil
+}
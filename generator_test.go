@@ -0,0 +1,141 @@
+package ulid
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGeneratorIsolation(t *testing.T) {
+	a, b := NewGenerator(), NewGenerator()
+	timestamp := uint64(time.Now().Add(96 * time.Hour).UnixMilli())
+
+	for range 3 {
+		if _, err := a.NewTime(timestamp); err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+	}
+	if _, sequence, err := b.NewTimeWithSequence(timestamp); err != nil || sequence != 0 {
+		t.Errorf("Expected independent sequence in a new generator, got %d, %v", sequence, err)
+	}
+
+	if got := a.Stats().Generated; got != 3 {
+		t.Errorf("Generated mismatch for a: got %d, expected 3", got)
+	}
+	if got := b.Stats().Generated; got != 1 {
+		t.Errorf("Generated mismatch for b: got %d, expected 1", got)
+	}
+}
+
+func TestGeneratorConcurrentMonotonic(t *testing.T) {
+	g := NewGenerator()
+	timestamp := uint64(time.Now().UnixMilli())
+
+	const workers, perWorker = 4, 250
+	ids := make([][]ULID, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWorker {
+				u, err := g.NewULIDTime(timestamp)
+				if err != nil {
+					t.Errorf("Error generating ULID: %v", err)
+					return
+				}
+				ids[w] = append(ids[w], u)
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[ULID]bool)
+	for _, list := range ids {
+		for i, u := range list {
+			if seen[u] {
+				t.Fatalf("Duplicate ULID: %s", u)
+			}
+			seen[u] = true
+			if i > 0 && u.Compare(list[i-1]) <= 0 {
+				t.Fatalf("Monotonicity failed within a worker: %s <= %s", u, list[i-1])
+			}
+		}
+	}
+}
+
+func TestGeneratorOptions(t *testing.T) {
+	current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var regressions int
+	g := NewGenerator(
+		WithClock(func() time.Time { return current }),
+		WithClockBackwardsHook(func(previous, current uint64) { regressions++ }),
+	)
+
+	u, err := g.NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if !u.Time().Equal(current) {
+		t.Errorf("Expected the configured clock, got %v", u.Time())
+	}
+
+	current = current.Add(-time.Second)
+	if _, err := g.NewULID(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if regressions != 1 {
+		t.Errorf("Expected one clock regression, got %d", regressions)
+	}
+}
+
+func BenchmarkGeneratorParallel(b *testing.B) {
+	g := NewGenerator()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = g.NewULID()
+		}
+	})
+}
+
+func BenchmarkGeneratorParallelNewMillisecond(b *testing.B) {
+	g := NewGenerator()
+	var timestamp atomic.Uint64
+	timestamp.Store(uint64(time.Now().UnixMilli()))
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = g.NewULIDTime(timestamp.Add(1))
+		}
+	})
+}
+
+func TestSetDefault(t *testing.T) {
+	previous := Default()
+	defer SetDefault(previous)
+
+	fixed := time.UnixMilli(1678886400000)
+	g := NewGenerator(WithClock(func() time.Time { return fixed }))
+	SetDefault(g)
+
+	if Default() != g {
+		t.Fatal("Default does not return the generator set with SetDefault")
+	}
+	u, err := NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if u.GetTime() != uint64(fixed.UnixMilli()) {
+		t.Errorf("Expected the package-level function to use the new default clock, got %d", u.GetTime())
+	}
+	if g.Stats().Generated != 1 {
+		t.Errorf("Expected the ID to be counted by the new default generator")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected SetDefault(nil) to panic")
+		}
+	}()
+	SetDefault(nil)
+}
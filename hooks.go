@@ -0,0 +1,38 @@
+package ulid
+
+// Hooks receives generation events from a Generator, e.g. to surface
+// anomalies in the traces or metrics of high-volume services. Hooks are
+// called synchronously on the generating goroutine, outside of any internal
+// lock, so implementations must be safe for concurrent use and fast.
+type Hooks interface {
+	// OnGenerate is called with every ID the Generator issues.
+	OnGenerate(u ULID)
+
+	// OnOverflow is called when the randomness of the millisecond timestamp
+	// is exhausted, after the overflow policy has been applied: once the ID
+	// moved to a later millisecond has been issued, before waiting for the
+	// next millisecond, or before the policy's error is returned.
+	OnOverflow(timestamp uint64, policy OverflowPolicy)
+
+	// OnEntropyError is called when reading the entropy source fails.
+	OnEntropyError(err error)
+}
+
+// WithHooks installs instrumentation hooks on the Generator. See the
+// ulidotel module for an OpenTelemetry implementation.
+func WithHooks(hooks Hooks) GeneratorOption {
+	return func(g *Generator) {
+		g.hooks = hooks
+	}
+}
+
+// notify reports the overflow and entropy failure events of a generation
+// attempt at timestamp to the hooks
+func (g *Generator) notify(timestamp uint64, events generatorEvents, err error) {
+	if events&eventOverflow != 0 {
+		g.hooks.OnOverflow(g.epoch+timestamp, g.overflowPolicy)
+	}
+	if events&eventEntropyFailure != 0 {
+		g.hooks.OnEntropyError(err)
+	}
+}
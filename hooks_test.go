@@ -0,0 +1,83 @@
+package ulid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingHooks is a Hooks implementation recording every event
+type recordingHooks struct {
+	mu        sync.Mutex
+	generated []ULID
+	overflows []OverflowPolicy
+	errors    []error
+}
+
+func (h *recordingHooks) OnGenerate(u ULID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.generated = append(h.generated, u)
+}
+
+func (h *recordingHooks) OnOverflow(_ uint64, policy OverflowPolicy) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.overflows = append(h.overflows, policy)
+}
+
+func (h *recordingHooks) OnEntropyError(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.errors = append(h.errors, err)
+}
+
+func TestWithHooksGenerate(t *testing.T) {
+	hooks := &recordingHooks{}
+	g := NewGenerator(WithHooks(hooks))
+
+	u, err := g.NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if len(hooks.generated) != 1 || hooks.generated[0] != u {
+		t.Errorf("Expected OnGenerate with %s, got %v", u, hooks.generated)
+	}
+}
+
+func TestWithHooksOverflow(t *testing.T) {
+	hooks := &recordingHooks{}
+	timestamp := uint64(time.Now().UnixMilli())
+
+	g := exhaustedGenerator(timestamp, WithHooks(hooks), WithOverflowPolicy(OverflowError))
+	if _, err := g.NewULIDTime(timestamp); !errors.Is(err, ErrMonotonicOverflow) {
+		t.Fatalf("Expected ErrMonotonicOverflow, got %v", err)
+	}
+	g = exhaustedGenerator(timestamp, WithHooks(hooks))
+	if _, err := g.NewULIDTime(timestamp); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+
+	want := []OverflowPolicy{OverflowError, OverflowBumpTimestamp}
+	if len(hooks.overflows) != len(want) || hooks.overflows[0] != want[0] || hooks.overflows[1] != want[1] {
+		t.Errorf("OnOverflow mismatch: got %v, expected %v", hooks.overflows, want)
+	}
+	if len(hooks.generated) != 1 {
+		t.Errorf("Expected OnGenerate only for the issued ID, got %d calls", len(hooks.generated))
+	}
+}
+
+func TestWithHooksEntropyError(t *testing.T) {
+	hooks := &recordingHooks{}
+	g := NewGenerator(WithHooks(hooks), WithEntropy(bytes.NewReader(nil)))
+
+	if _, err := g.New(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected io.EOF, got %v", err)
+	}
+	if len(hooks.errors) != 1 || !errors.Is(hooks.errors[0], io.EOF) {
+		t.Errorf("Expected OnEntropyError with io.EOF, got %v", hooks.errors)
+	}
+}
@@ -0,0 +1,47 @@
+package ulid
+
+import (
+	mathrand "math/rand/v2"
+	"time"
+)
+
+// SetTimestampJitter makes New add a uniformly distributed random offset in
+// the range [-window, +window] to the embedded timestamp, reducing how precisely a
+// public ID reveals its creation time. The jitter is applied with millisecond
+// resolution; a window below one millisecond disables it.
+//
+// Jitter trades ordering for privacy: IDs generated less than 2*window apart may
+// sort in either order, and monotonicity is only preserved between IDs that
+// happen to receive the same jittered millisecond. IDs remain sortable at a
+// granularity coarser than the jitter window. NewTime is never jittered.
+func SetTimestampJitter(window time.Duration) {
+	Default().SetTimestampJitter(window)
+}
+
+// SetTimestampJitter sets the timestamp jitter window of g, as with the
+// package-level SetTimestampJitter.
+func (g *Generator) SetTimestampJitter(window time.Duration) {
+	g.timestampJitter.Store(jitterWindow(window))
+}
+
+// jitterWindow converts a jitter window to whole milliseconds
+func jitterWindow(window time.Duration) uint64 {
+	if window < 0 {
+		return 0
+	}
+	return uint64(window.Milliseconds())
+}
+
+// jitterTimestamp offsets timestamp by a random amount within [-window, +window],
+// clamping the result to the valid timestamp range
+func jitterTimestamp(timestamp, window uint64) uint64 {
+	offset := mathrand.Uint64N(2*window + 1)
+	if timestamp+offset < window {
+		return 0
+	}
+	timestamp = timestamp + offset - window
+	if timestamp > maxTimestamp {
+		return maxTimestamp
+	}
+	return timestamp
+}
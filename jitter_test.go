@@ -0,0 +1,47 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetTimestampJitter(t *testing.T) {
+	current := time.Now()
+	timeNow = func() time.Time { return current }
+	defer func() { timeNow = time.Now }()
+
+	SetTimestampJitter(500 * time.Millisecond)
+	defer SetTimestampJitter(0)
+
+	base := uint64(current.UnixMilli())
+	distinct := make(map[uint64]bool)
+	for range 200 {
+		ulidStr, err := New()
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		parsed, err := Parse(ulidStr)
+		if err != nil {
+			t.Fatalf("Error parsing ULID: %v", err)
+		}
+		if parsed.GetTime() < base-500 || parsed.GetTime() > base+500 {
+			t.Fatalf("Jittered timestamp out of bounds: got %d, base %d", parsed.GetTime(), base)
+		}
+		distinct[parsed.GetTime()] = true
+	}
+
+	if len(distinct) < 2 {
+		t.Errorf("Expected jitter to produce varying timestamps")
+	}
+}
+
+func TestJitterTimestampClamps(t *testing.T) {
+	for range 100 {
+		if got := jitterTimestamp(0, 10); got > 10 {
+			t.Fatalf("Jitter near zero out of bounds: got %d", got)
+		}
+		if got := jitterTimestamp(maxTimestamp, 10); got < maxTimestamp-10 || got > maxTimestamp {
+			t.Fatalf("Jitter near max out of bounds: got %d", got)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package ulid
+
+import (
+	"encoding/base64"
+	"errors"
+	"sync/atomic"
+)
+
+// JSONFormat controls the JSON representation of a ULID.
+type JSONFormat int32
+
+const (
+	// JSONFormatString marshals a ULID as its 26-character string form.
+	JSONFormatString JSONFormat = iota
+
+	// JSONFormatBase64 marshals a ULID as the standard base64 encoding of
+	// its 16-byte binary form (24 characters).
+	JSONFormatBase64
+)
+
+// jsonFormat holds the package-wide JSONFormat
+var jsonFormat atomic.Int32
+
+// base64Length is the length of a base64-encoded ULID
+var base64Length = base64.StdEncoding.EncodedLen(totalBytes)
+
+// SetJSONFormat sets the representation produced by MarshalJSON for the whole
+// process. The default is JSONFormatString. UnmarshalJSON accepts both forms
+// regardless of this setting, so readers and writers can migrate independently.
+func SetJSONFormat(format JSONFormat) {
+	jsonFormat.Store(int32(format))
+}
+
+// JSONZeroMode controls how the zero ULID is represented by MarshalJSON.
+type JSONZeroMode int32
+
+const (
+	// JSONZeroString marshals the zero ULID as "00000000000000000000000000".
+	JSONZeroString JSONZeroMode = iota
+
+	// JSONZeroNull marshals the zero ULID as null.
+	JSONZeroNull
+)
+
+// jsonZeroMode holds the package-wide JSONZeroMode
+var jsonZeroMode atomic.Int32
+
+// SetJSONZeroMode sets how the zero ULID is marshaled to JSON for the whole
+// process. The default is JSONZeroString. Independently of this setting, JSON
+// null always unmarshals to the zero ULID, and struct fields tagged with
+// `json:",omitzero"` are omitted when zero because ULID implements IsZero.
+func SetJSONZeroMode(mode JSONZeroMode) {
+	jsonZeroMode.Store(int32(mode))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (u ULID) MarshalJSON() ([]byte, error) {
+	if u.IsZero() && JSONZeroMode(jsonZeroMode.Load()) == JSONZeroNull {
+		return []byte("null"), nil
+	}
+	return u.marshalJSONString(), nil
+}
+
+// marshalJSONString encodes u as a JSON string in the configured JSONFormat,
+// regardless of the JSONZeroMode
+func (u ULID) marshalJSONString() []byte {
+	b := make([]byte, 0, encodedLength+2)
+	b = append(b, '"')
+	if JSONFormat(jsonFormat.Load()) == JSONFormatBase64 {
+		data := u.bytes()
+		b = base64.StdEncoding.AppendEncode(b, data[:])
+	} else {
+		b = append(b, u.String()...)
+	}
+	b = append(b, '"')
+	return b
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts both the string and
+// the base64 form; JSON null yields the zero ULID.
+func (u *ULID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*u = ULID{}
+		return nil
+	}
+
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return errors.New("ULID must be a JSON string")
+	}
+
+	value := data[1 : len(data)-1]
+	if len(value) == base64Length {
+		var raw [totalBytes]byte
+		if _, err := base64.StdEncoding.Decode(raw[:], value); err != nil {
+			return errors.New("invalid base64 ULID")
+		}
+		*u = fromBytes(raw)
+		return nil
+	}
+
+	parsed, err := Parse(string(value))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
@@ -0,0 +1,102 @@
+package ulid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	ulidStr, err := New()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	u, err := Parse(ulidStr)
+	if err != nil {
+		t.Fatalf("Error parsing ULID: %v", err)
+	}
+
+	data, err := json.Marshal(u)
+	if err != nil {
+		t.Fatalf("Error marshaling ULID: %v", err)
+	}
+	if string(data) != `"`+ulidStr+`"` {
+		t.Errorf("JSON mismatch: got %s, expected %q", data, ulidStr)
+	}
+
+	var decoded ULID
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Error unmarshaling ULID: %v", err)
+	}
+	if decoded != u {
+		t.Errorf("Round trip mismatch: got %v, expected %v", decoded, u)
+	}
+
+	if err := json.Unmarshal([]byte(`123`), &decoded); err == nil {
+		t.Errorf("Expected error for non-string JSON value")
+	}
+	if err := json.Unmarshal([]byte(`"invalid"`), &decoded); err == nil {
+		t.Errorf("Expected error for invalid ULID string")
+	}
+}
+
+func TestJSONZeroMode(t *testing.T) {
+	defer SetJSONZeroMode(JSONZeroString)
+
+	type record struct {
+		ID       ULID `json:"id"`
+		ParentID ULID `json:"parent_id,omitzero"`
+	}
+
+	data, err := json.Marshal(record{})
+	if err != nil {
+		t.Fatalf("Error marshaling record: %v", err)
+	}
+	if string(data) != `{"id":"00000000000000000000000000"}` {
+		t.Errorf("Default zero JSON mismatch: got %s", data)
+	}
+
+	SetJSONZeroMode(JSONZeroNull)
+	data, err = json.Marshal(record{})
+	if err != nil {
+		t.Fatalf("Error marshaling record: %v", err)
+	}
+	if string(data) != `{"id":null}` {
+		t.Errorf("Null zero JSON mismatch: got %s", data)
+	}
+
+	decoded := record{ID: ULID{timestamp: 1}}
+	if err := json.Unmarshal([]byte(`{"id":null}`), &decoded); err != nil {
+		t.Fatalf("Error unmarshaling record: %v", err)
+	}
+	if !decoded.ID.IsZero() {
+		t.Errorf("Expected null to unmarshal to the zero ULID, got %v", decoded.ID)
+	}
+}
+
+func TestJSONFormatBase64(t *testing.T) {
+	defer SetJSONFormat(JSONFormatString)
+
+	u := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+
+	SetJSONFormat(JSONFormatBase64)
+	data, err := json.Marshal(u)
+	if err != nil {
+		t.Fatalf("Error marshaling ULID: %v", err)
+	}
+	if string(data) != `"AYblbXAAAQIDBAUGBwgJCg=="` {
+		t.Errorf("Base64 JSON mismatch: got %s", data)
+	}
+
+	SetJSONFormat(JSONFormatString)
+	var decoded ULID
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Error unmarshaling base64 ULID: %v", err)
+	}
+	if decoded != u {
+		t.Errorf("Round trip mismatch: got %v, expected %v", decoded, u)
+	}
+
+	if err := json.Unmarshal([]byte(`"!!!!!!!!!!!!!!!!!!!!!!=="`), &decoded); err == nil {
+		t.Errorf("Expected error for invalid base64")
+	}
+}
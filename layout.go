@@ -0,0 +1,82 @@
+package ulid
+
+import "fmt"
+
+// ByteLayout describes how the 16 bytes of a ULID are arranged when stored
+// in a foreign GUID type.
+type ByteLayout int
+
+const (
+	// LayoutBigEndian is the canonical layout: timestamp first, big-endian.
+	LayoutBigEndian ByteLayout = iota
+
+	// LayoutMixedEndian is the .NET Guid.ToByteArray layout, with the first
+	// three UUID groups stored little-endian. A Guid built from these bytes
+	// prints the same 8-4-4-4-12 string as the ULID's UUID form, but does not
+	// sort by time in SQL Server.
+	LayoutMixedEndian
+
+	// LayoutSQLServer arranges the bytes so that SQL Server, which compares
+	// uniqueidentifier values starting from the last group, orders the stored
+	// GUIDs exactly as the ULIDs they encode.
+	LayoutSQLServer
+)
+
+// layoutOrders maps each layout to the ULID byte index stored at each position
+var layoutOrders = [...][totalBytes]byte{
+	LayoutBigEndian:   {0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	LayoutMixedEndian: {3, 2, 1, 0, 5, 4, 7, 6, 8, 9, 10, 11, 12, 13, 14, 15},
+	LayoutSQLServer:   {12, 13, 14, 15, 10, 11, 8, 9, 6, 7, 0, 1, 2, 3, 4, 5},
+}
+
+// String returns the name of the layout.
+func (l ByteLayout) String() string {
+	switch l {
+	case LayoutBigEndian:
+		return "big-endian"
+	case LayoutMixedEndian:
+		return "mixed-endian"
+	case LayoutSQLServer:
+		return "sqlserver"
+	default:
+		return fmt.Sprintf("ByteLayout(%d)", int(l))
+	}
+}
+
+// LayoutBytes returns the 16-byte form of the ULID arranged in the given
+// layout. It panics if layout is not one of the defined constants.
+func (u ULID) LayoutBytes(layout ByteLayout) [totalBytes]byte {
+	order := layout.order()
+	data := u.bytes()
+
+	var out [totalBytes]byte
+	for i, src := range order {
+		out[i] = data[src]
+	}
+	return out
+}
+
+// FromLayoutBytes reconstructs a ULID from 16 bytes stored in the given
+// layout, reversing LayoutBytes.
+func FromLayoutBytes(b []byte, layout ByteLayout) (ULID, error) {
+	if layout < 0 || int(layout) >= len(layoutOrders) {
+		return ULID{}, fmt.Errorf("unknown byte layout %v", layout)
+	}
+	if len(b) != totalBytes {
+		return ULID{}, lengthError(totalBytes, len(b))
+	}
+
+	var data [totalBytes]byte
+	for i, src := range layoutOrders[layout] {
+		data[src] = b[i]
+	}
+	return fromBytes(data), nil
+}
+
+// order returns the byte permutation of the layout
+func (l ByteLayout) order() *[totalBytes]byte {
+	if l < 0 || int(l) >= len(layoutOrders) {
+		panic(fmt.Sprintf("ulid: unknown byte layout %v", l))
+	}
+	return &layoutOrders[l]
+}
@@ -0,0 +1,76 @@
+package ulid
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+// sqlServerOrder is the byte comparison order SQL Server applies to uniqueidentifier values
+var sqlServerOrder = [16]int{10, 11, 12, 13, 14, 15, 8, 9, 6, 7, 4, 5, 0, 1, 2, 3}
+
+func sqlServerCompare(a, b [16]byte) int {
+	for _, i := range sqlServerOrder {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func TestLayoutBytes(t *testing.T) {
+	u := fromBytes([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+
+	mixed := u.LayoutBytes(LayoutMixedEndian)
+	wantMixed := []byte{3, 2, 1, 0, 5, 4, 7, 6, 8, 9, 10, 11, 12, 13, 14, 15}
+	if !bytes.Equal(mixed[:], wantMixed) {
+		t.Errorf("Mixed-endian layout mismatch: got %v, expected %v", mixed, wantMixed)
+	}
+
+	for _, layout := range []ByteLayout{LayoutBigEndian, LayoutMixedEndian, LayoutSQLServer} {
+		data := u.LayoutBytes(layout)
+		back, err := FromLayoutBytes(data[:], layout)
+		if err != nil {
+			t.Fatalf("Error decoding %v layout: %v", layout, err)
+		}
+		if back != u {
+			t.Errorf("Round trip failed for %v layout: got %s, expected %s", layout, back, u)
+		}
+	}
+
+	if _, err := FromLayoutBytes(make([]byte, 15), LayoutSQLServer); err == nil {
+		t.Errorf("Expected error for short input")
+	}
+	if _, err := FromLayoutBytes(make([]byte, 16), ByteLayout(42)); err == nil {
+		t.Errorf("Expected error for unknown layout")
+	}
+}
+
+func TestLayoutSQLServerOrdering(t *testing.T) {
+	timestamp := uint64(1700000000000)
+	ids := make([]string, 0, 1000)
+	for i := range 1000 {
+		ulidStr, err := NewTime(timestamp + uint64(i%7)*86400000)
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		ids = append(ids, ulidStr)
+	}
+	slices.Sort(ids)
+
+	var previous [16]byte
+	for _, ulidStr := range ids {
+		u, err := Parse(ulidStr)
+		if err != nil {
+			t.Fatalf("Error parsing ULID: %v", err)
+		}
+		stored := u.LayoutBytes(LayoutSQLServer)
+		if sqlServerCompare(previous, stored) >= 0 {
+			t.Fatalf("SQL Server ordering differs from ULID ordering at %s", ulidStr)
+		}
+		previous = stored
+	}
+}
@@ -0,0 +1,30 @@
+package ulid
+
+// MustNew is like New but panics on error. The panic value is the error
+// returned by New. It is intended for tests, fixtures and package-level
+// variable initialization.
+func MustNew() string {
+	ulidStr, err := New()
+	if err != nil {
+		panic(err)
+	}
+	return ulidStr
+}
+
+// MustNewTime is like NewTime but panics on error.
+func MustNewTime(timestamp uint64) string {
+	ulidStr, err := NewTime(timestamp)
+	if err != nil {
+		panic(err)
+	}
+	return ulidStr
+}
+
+// MustParse is like Parse but panics on error.
+func MustParse(s string) ULID {
+	u, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
@@ -0,0 +1,39 @@
+package ulid
+
+import "testing"
+
+func TestMustParse(t *testing.T) {
+	u := MustParse("01arz3ndektsv4rrffq69g5far")
+	if u.String() != "01arz3ndektsv4rrffq69g5far" {
+		t.Errorf("MustParse mismatch: got %s", u)
+	}
+
+	_, wantErr := Parse("invalid")
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok {
+			t.Fatalf("Expected panic with an error, got %v", r)
+		}
+		if err.Error() != wantErr.Error() {
+			t.Errorf("Panic message mismatch: got %q, expected %q", err, wantErr)
+		}
+	}()
+	MustParse("invalid")
+}
+
+func TestMustNewTime(t *testing.T) {
+	if _, err := Parse(MustNew()); err != nil {
+		t.Errorf("MustNew returned an invalid ULID: %v", err)
+	}
+	if _, err := Parse(MustNewTime(1700000000000)); err != nil {
+		t.Errorf("MustNewTime returned an invalid ULID: %v", err)
+	}
+
+	defer func() {
+		if _, ok := recover().(error); !ok {
+			t.Errorf("Expected panic with an error for timestamp overflow")
+		}
+	}()
+	MustNewTime(maxTimestamp + 1)
+}
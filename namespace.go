@@ -0,0 +1,48 @@
+package ulid
+
+import "crypto/sha256"
+
+// NewFromNamespace derives a ULID for name within the namespace ns, using the
+// current time of the default generator, including its clock and epoch, as
+// the timestamp. The randomness component is the leading 80 bits of
+// SHA-256(ns || name), so the same namespace and name always yield the same
+// entropy. Use NewFromNamespaceTime with a fixed timestamp, such as the
+// creation time of the external record, for fully reproducible IDs.
+//
+// Namespace-derived ULIDs do not advance the monotonic generator state.
+func NewFromNamespace(ns ULID, name []byte) (string, error) {
+	timestamp := Default().clockTimestamp()
+	if timestamp > maxTimestamp {
+		return "", ErrTimestampOverflow
+	}
+	return namespaceULID(ns, name, timestamp), nil
+}
+
+// NewFromNamespaceTime derives a ULID for name within the namespace ns with
+// the given timestamp (Unix milliseconds), relative to the default
+// generator's epoch as with NewTime. The result is fully deterministic.
+func NewFromNamespaceTime(ns ULID, name []byte, timestamp uint64) (string, error) {
+	if timestamp > maxTimestamp {
+		return "", ErrTimestampOverflow
+	}
+	timestamp, err := Default().sinceEpoch(timestamp)
+	if err != nil {
+		return "", err
+	}
+	return namespaceULID(ns, name, timestamp), nil
+}
+
+// namespaceULID derives the ULID for name within ns at timestamp, given in
+// milliseconds since the default generator's epoch
+func namespaceULID(ns ULID, name []byte, timestamp uint64) string {
+	nsBytes := ns.bytes()
+	h := sha256.New()
+	h.Write(nsBytes[:])
+	h.Write(name)
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+
+	u := ULID{timestamp: timestamp}
+	copy(u.randomness[:], sum[:randomnessBytes])
+	return u.String()
+}
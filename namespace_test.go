@@ -0,0 +1,80 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewFromNamespaceTime(t *testing.T) {
+	ns, err := Parse("01arz3ndektsv4rrffq69g5fav")
+	if err != nil {
+		t.Fatalf("Error parsing namespace: %v", err)
+	}
+	timestamp := uint64(1700000000000)
+
+	first, err := NewFromNamespaceTime(ns, []byte("order-42"), timestamp)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	second, err := NewFromNamespaceTime(ns, []byte("order-42"), timestamp)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if first != second {
+		t.Errorf("Expected deterministic ULID, got %s and %s", first, second)
+	}
+
+	other, err := NewFromNamespaceTime(ns, []byte("order-43"), timestamp)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if other == first {
+		t.Errorf("Expected different names to produce different ULIDs")
+	}
+
+	u, err := Parse(first)
+	if err != nil {
+		t.Fatalf("Error parsing ULID: %v", err)
+	}
+	if u.GetTime() != timestamp {
+		t.Errorf("Timestamp mismatch: got %d, expected %d", u.GetTime(), timestamp)
+	}
+
+	if _, err := NewFromNamespaceTime(ns, nil, maxTimestamp+1); err == nil {
+		t.Errorf("Expected error for timestamp overflow")
+	}
+}
+
+func TestNewFromNamespace(t *testing.T) {
+	ns, err := Parse("01arz3ndektsv4rrffq69g5fav")
+	if err != nil {
+		t.Fatalf("Error parsing namespace: %v", err)
+	}
+	previous := Default()
+	defer SetDefault(previous)
+	epoch := time.UnixMilli(1600000000000)
+	SetDefault(NewGenerator(WithEpoch(epoch), WithClock(func() time.Time { return time.UnixMilli(1700000000000) })))
+
+	first, err := NewFromNamespace(ns, []byte("order-42"))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	second, err := NewFromNamespace(ns, []byte("order-42"))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if first != second {
+		t.Errorf("Expected identical IDs at the same time, got %s and %s", first, second)
+	}
+	if parsed, _ := Parse(first); !parsed.TimeFrom(epoch).Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("Timestamp mismatch: got %v relative to the epoch", parsed.TimeFrom(epoch))
+	}
+
+	pinned, err := NewFromNamespaceTime(ns, []byte("order-42"), 1700000000000)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if pinned != first {
+		t.Errorf("Expected the pinned time to match the clock, got %s and %s", pinned, first)
+	}
+}
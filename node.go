@@ -0,0 +1,230 @@
+package ulid
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	mathrand "math/rand/v2"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxNodeBits is the largest node ID width supported by the allocators
+const maxNodeBits = 32
+
+// NodeAllocator assigns node IDs for embedding in the randomness component,
+// so that generators on different hosts produce non-colliding ULIDs.
+type NodeAllocator interface {
+	// Allocate returns a node ID that fits in the given number of bits.
+	Allocate(ctx context.Context, bits int) (uint32, error)
+}
+
+// checkNodeBits validates a node ID width
+func checkNodeBits(bits int) error {
+	if bits < 1 || bits > maxNodeBits {
+		return fmt.Errorf("node bits must be between 1 and %d, got %d", maxNodeBits, bits)
+	}
+	return nil
+}
+
+// StaticNode is a NodeAllocator returning a fixed, configured node ID.
+type StaticNode uint32
+
+// Allocate returns the static node ID, or an error if it does not fit in bits.
+func (n StaticNode) Allocate(_ context.Context, bits int) (uint32, error) {
+	if err := checkNodeBits(bits); err != nil {
+		return 0, err
+	}
+	if bits < maxNodeBits && uint32(n) >= 1<<bits {
+		return 0, fmt.Errorf("node ID %d does not fit in %d bits", n, bits)
+	}
+	return uint32(n), nil
+}
+
+// HostnameNode is a NodeAllocator deriving the node ID from a hash of the
+// host name. It needs no coordination, but distinct hosts may collide; the
+// probability grows with the number of hosts relative to 2^bits.
+type HostnameNode struct{}
+
+// Allocate returns the FNV-1a hash of the host name truncated to bits.
+func (HostnameNode) Allocate(_ context.Context, bits int) (uint32, error) {
+	if err := checkNodeBits(bits); err != nil {
+		return 0, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New32a()
+	h.Write([]byte(hostname))
+	return h.Sum32() >> (maxNodeBits - bits), nil
+}
+
+// nodeStamp forces the top bits of generated randomness to a node ID
+type nodeStamp struct {
+	mask, prefix [randomnessBytes]byte
+}
+
+// WithNode reserves the top bits of the randomness component for node, as
+// in Snowflake's worker ID. IDs from generators with distinct node IDs of the
+// same width never collide, even with degraded entropy, and ULID.Node
+// recovers the origin from the ID alone. The other 80 - bits bits of
+// randomness are generated and incremented as usual; exhausting them within a
+// millisecond counts as an overflow.
+//
+// Node IDs are typically obtained from a NodeAllocator. WithNode panics if
+// bits is not between 1 and 32 or node does not fit in bits.
+func WithNode(node uint32, bits int) GeneratorOption {
+	if err := checkNodeBits(bits); err != nil {
+		panic("ulid: " + err.Error())
+	}
+	if bits < maxNodeBits && node >= 1<<bits {
+		panic(fmt.Sprintf("ulid: node ID %d does not fit in %d bits", node, bits))
+	}
+
+	var stamp nodeStamp
+	var mask, prefix [4]byte
+	binary.BigEndian.PutUint32(mask[:], ^uint32(0)<<(maxNodeBits-bits))
+	binary.BigEndian.PutUint32(prefix[:], node<<(maxNodeBits-bits))
+	copy(stamp.mask[:], mask[:])
+	copy(stamp.prefix[:], prefix[:])
+
+	return func(g *Generator) {
+		g.node = &stamp
+	}
+}
+
+// apply overwrites the node bits of randomness
+func (s *nodeStamp) apply(randomness *[randomnessBytes]byte) {
+	for i := range s.mask {
+		randomness[i] = randomness[i]&^s.mask[i] | s.prefix[i]
+	}
+}
+
+// matches reports whether randomness carries the node ID
+func (s *nodeStamp) matches(randomness *[randomnessBytes]byte) bool {
+	for i := range s.mask {
+		if randomness[i]&s.mask[i] != s.prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Node returns the node ID stored in the top bits of the randomness component
+// by a generator configured with WithNode(node, bits). It panics if bits is
+// not between 1 and 32.
+func (u ULID) Node(bits int) uint32 {
+	if err := checkNodeBits(bits); err != nil {
+		panic("ulid: " + err.Error())
+	}
+	return binary.BigEndian.Uint32(u.randomness[:4]) >> (maxNodeBits - bits)
+}
+
+// LeaseStore is the minimal key-value interface required by LeaseAllocator.
+// It maps naturally onto Redis (SET NX PX, Lua check-and-set) and etcd
+// (transactions on leased keys).
+type LeaseStore interface {
+	// SetNX stores value under key with the given TTL if key does not exist,
+	// reporting whether it was stored.
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+
+	// Refresh extends the TTL of key if it still holds value, reporting
+	// whether the lease is still held.
+	Refresh(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+
+	// Delete removes key if it still holds value.
+	Delete(ctx context.Context, key, value string) error
+}
+
+// LeaseAllocator is a NodeAllocator that claims a node ID by acquiring a
+// lease on a key in a shared store. The lease must be renewed within its TTL
+// by calling Renew, and should be released on shutdown.
+type LeaseAllocator struct {
+	store  LeaseStore
+	prefix string
+	ttl    time.Duration
+	owner  string
+
+	mu  sync.Mutex
+	key string
+}
+
+// NewLeaseAllocator returns a LeaseAllocator storing leases under
+// prefix+nodeID with the given TTL.
+func NewLeaseAllocator(store LeaseStore, prefix string, ttl time.Duration) (*LeaseAllocator, error) {
+	owner, err := New()
+	if err != nil {
+		return nil, err
+	}
+	return &LeaseAllocator{store: store, prefix: prefix, ttl: ttl, owner: owner}, nil
+}
+
+// Allocate claims the first free node ID, probing from a random starting
+// point to spread concurrent allocations.
+func (a *LeaseAllocator) Allocate(ctx context.Context, bits int) (uint32, error) {
+	if err := checkNodeBits(bits); err != nil {
+		return 0, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.key != "" {
+		return 0, errors.New("lease allocator already holds a node ID")
+	}
+
+	size := uint64(1) << bits
+	start := mathrand.Uint64N(size)
+	for i := range size {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		id := uint32((start + i) % size)
+		key := a.prefix + strconv.FormatUint(uint64(id), 10)
+		ok, err := a.store.SetNX(ctx, key, a.owner, a.ttl)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			a.key = key
+			return id, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no free node ID in %d bits", bits)
+}
+
+// Renew extends the lease on the allocated node ID. An error means the lease
+// was lost and the node ID must no longer be used.
+func (a *LeaseAllocator) Renew(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.key == "" {
+		return errors.New("lease allocator holds no node ID")
+	}
+	ok, err := a.store.Refresh(ctx, a.key, a.owner, a.ttl)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		a.key = ""
+		return errors.New("node ID lease lost")
+	}
+	return nil
+}
+
+// Release gives up the allocated node ID.
+func (a *LeaseAllocator) Release(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.key == "" {
+		return nil
+	}
+	err := a.store.Delete(ctx, a.key, a.owner)
+	a.key = ""
+	return err
+}
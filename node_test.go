@@ -0,0 +1,167 @@
+package ulid
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryLeaseStore is an in-memory LeaseStore ignoring TTLs
+type memoryLeaseStore struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (s *memoryLeaseStore) SetNX(_ context.Context, key, value string, _ time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.values[key]; ok {
+		return false, nil
+	}
+	s.values[key] = value
+	return true, nil
+}
+
+func (s *memoryLeaseStore) Refresh(_ context.Context, key, value string, _ time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.values[key] == value, nil
+}
+
+func (s *memoryLeaseStore) Delete(_ context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.values[key] == value {
+		delete(s.values, key)
+	}
+	return nil
+}
+
+func TestStaticNode(t *testing.T) {
+	id, err := StaticNode(5).Allocate(context.Background(), 4)
+	if err != nil || id != 5 {
+		t.Errorf("Allocate mismatch: got %d, %v; expected 5", id, err)
+	}
+	if _, err := StaticNode(16).Allocate(context.Background(), 4); err == nil {
+		t.Errorf("Expected error for node ID exceeding bit width")
+	}
+	if _, err := StaticNode(1).Allocate(context.Background(), 0); err == nil {
+		t.Errorf("Expected error for zero bit width")
+	}
+}
+
+func TestHostnameNode(t *testing.T) {
+	a, err := HostnameNode{}.Allocate(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("Error allocating node ID: %v", err)
+	}
+	b, _ := HostnameNode{}.Allocate(context.Background(), 10)
+	if a != b || a >= 1<<10 {
+		t.Errorf("Expected stable node ID within 10 bits, got %d and %d", a, b)
+	}
+}
+
+func TestLeaseAllocator(t *testing.T) {
+	store := &memoryLeaseStore{values: make(map[string]string)}
+	ctx := context.Background()
+
+	seen := make(map[uint32]bool)
+	var allocators []*LeaseAllocator
+	for range 4 {
+		a, err := NewLeaseAllocator(store, "ulid/nodes/", time.Minute)
+		if err != nil {
+			t.Fatalf("Error creating allocator: %v", err)
+		}
+		id, err := a.Allocate(ctx, 2)
+		if err != nil {
+			t.Fatalf("Error allocating node ID: %v", err)
+		}
+		if seen[id] {
+			t.Fatalf("Node ID %d allocated twice", id)
+		}
+		seen[id] = true
+		allocators = append(allocators, a)
+	}
+
+	extra, _ := NewLeaseAllocator(store, "ulid/nodes/", time.Minute)
+	if _, err := extra.Allocate(ctx, 2); err == nil {
+		t.Fatalf("Expected error when all node IDs are taken")
+	}
+
+	if err := allocators[0].Renew(ctx); err != nil {
+		t.Errorf("Error renewing lease: %v", err)
+	}
+	if err := allocators[0].Release(ctx); err != nil {
+		t.Errorf("Error releasing lease: %v", err)
+	}
+	if _, err := extra.Allocate(ctx, 2); err != nil {
+		t.Errorf("Expected released node ID to be reusable, got error: %v", err)
+	}
+	if err := allocators[0].Renew(ctx); err == nil {
+		t.Errorf("Expected error renewing a released lease")
+	}
+}
+
+func TestWithNode(t *testing.T) {
+	const node, bits = 0x2a5, 10
+	g := NewGenerator(WithNode(node, bits))
+	timestamp := uint64(time.Now().UnixMilli())
+
+	previous := ULID{}
+	for range 1000 {
+		u, err := g.NewULIDTime(timestamp)
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		if got := u.Node(bits); got != node {
+			t.Fatalf("Node mismatch: got %#x, expected %#x", got, node)
+		}
+		if u.Compare(previous) <= 0 {
+			t.Fatalf("Not monotonic: %s <= %s", u, previous)
+		}
+		previous = u
+	}
+}
+
+func TestWithNodeOverflow(t *testing.T) {
+	const node, bits = 1, 2
+	g := NewGenerator(WithNode(node, bits))
+	timestamp := uint64(time.Now().UnixMilli())
+
+	// Exhaust the randomness below the node bits, so that incrementing
+	// would carry into them
+	state := &generatorState{lastTime: timestamp, burst: 1}
+	for i := range state.lastRandomness {
+		state.lastRandomness[i] = 0xFF
+	}
+	state.lastRandomness[0] = node<<6 | 0x3F
+	g.state.Store(state)
+
+	u, err := g.NewULIDTime(timestamp)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if u.GetTime() != timestamp+1 {
+		t.Errorf("Expected overflow into the next millisecond, got timestamp %d for %d", u.GetTime(), timestamp)
+	}
+	if got := u.Node(bits); got != node {
+		t.Errorf("Node mismatch: got %d, expected %d", got, node)
+	}
+}
+
+func TestWithNodeInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		node uint32
+		bits int
+	}{{0, 0}, {0, 33}, {4, 2}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for node %d in %d bits", tt.node, tt.bits)
+				}
+			}()
+			WithNode(tt.node, tt.bits)
+		}()
+	}
+}
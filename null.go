@@ -0,0 +1,76 @@
+package ulid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// NullULID represents a ULID that may be null, mirroring sql.NullString. It
+// implements sql.Scanner, driver.Valuer and JSON marshaling, with SQL NULL
+// and JSON null mapping to Valid == false.
+type NullULID struct {
+	ULID  ULID
+	Valid bool // Valid is true if ULID is not NULL
+}
+
+// Scan implements sql.Scanner. It accepts NULL, the string form, and the
+// 16-byte binary form stored in BINARY(16) columns.
+func (n *NullULID) Scan(src any) error {
+	var (
+		u   ULID
+		err error
+	)
+	switch v := src.(type) {
+	case nil:
+		*n = NullULID{}
+		return nil
+	case string:
+		u, err = Parse(v)
+	case []byte:
+		if len(v) == totalBytes {
+			u, err = FromBytes(v)
+		} else {
+			u, err = Parse(string(v))
+		}
+	default:
+		return fmt.Errorf("cannot scan %T into NullULID", src)
+	}
+	if err != nil {
+		return err
+	}
+	*n = NullULID{ULID: u, Valid: true}
+	return nil
+}
+
+// Value implements driver.Valuer, returning nil when the ULID is not valid
+// and the string form otherwise.
+func (n NullULID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.ULID.String(), nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding an invalid NullULID as null.
+// A valid zero ULID is always encoded as a string, even under JSONZeroNull, so
+// that it survives a round trip.
+func (n NullULID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.ULID.marshalJSONString(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null yields an invalid
+// NullULID.
+func (n *NullULID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullULID{}
+		return nil
+	}
+	if err := n.ULID.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
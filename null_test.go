@@ -0,0 +1,111 @@
+package ulid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*NullULID)(nil)
+	_ driver.Valuer = NullULID{}
+)
+
+func TestNullULIDScan(t *testing.T) {
+	u, err := NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	data := u.Bytes()
+
+	for _, src := range []any{u.String(), []byte(u.String()), data[:]} {
+		var n NullULID
+		if err := n.Scan(src); err != nil {
+			t.Fatalf("Error scanning %T: %v", src, err)
+		}
+		if !n.Valid || n.ULID != u {
+			t.Errorf("Scan mismatch for %T: got %+v", src, n)
+		}
+	}
+
+	n := NullULID{ULID: u, Valid: true}
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Error scanning nil: %v", err)
+	}
+	if n.Valid {
+		t.Errorf("Expected NULL to scan as invalid")
+	}
+	if err := n.Scan(42); err == nil {
+		t.Errorf("Expected error scanning an int")
+	}
+	if err := n.Scan("invalid"); err == nil {
+		t.Errorf("Expected error scanning an invalid string")
+	}
+
+	if v, err := (NullULID{}).Value(); err != nil || v != nil {
+		t.Errorf("Expected nil value for invalid NullULID, got %v, %v", v, err)
+	}
+	if v, err := (NullULID{ULID: u, Valid: true}).Value(); err != nil || v != u.String() {
+		t.Errorf("Expected string value, got %v, %v", v, err)
+	}
+}
+
+func TestNullULIDJSON(t *testing.T) {
+	type record struct {
+		ParentID NullULID `json:"parent_id"`
+	}
+
+	data, err := json.Marshal(record{})
+	if err != nil {
+		t.Fatalf("Error marshaling record: %v", err)
+	}
+	if string(data) != `{"parent_id":null}` {
+		t.Errorf("JSON mismatch: got %s", data)
+	}
+
+	u, err := NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	data, err = json.Marshal(record{ParentID: NullULID{ULID: u, Valid: true}})
+	if err != nil {
+		t.Fatalf("Error marshaling record: %v", err)
+	}
+
+	var decoded record
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Error unmarshaling record: %v", err)
+	}
+	if !decoded.ParentID.Valid || decoded.ParentID.ULID != u {
+		t.Errorf("Round trip mismatch: got %+v", decoded.ParentID)
+	}
+
+	if err := json.Unmarshal([]byte(`{"parent_id":null}`), &decoded); err != nil {
+		t.Fatalf("Error unmarshaling record: %v", err)
+	}
+	if decoded.ParentID.Valid {
+		t.Errorf("Expected null to unmarshal as invalid")
+	}
+}
+
+func TestNullULIDJSONValidZero(t *testing.T) {
+	defer SetJSONZeroMode(JSONZeroString)
+	SetJSONZeroMode(JSONZeroNull)
+
+	data, err := json.Marshal(NullULID{Valid: true})
+	if err != nil {
+		t.Fatalf("Error marshaling NullULID: %v", err)
+	}
+	if string(data) != `"00000000000000000000000000"` {
+		t.Errorf("Expected a valid zero ULID to marshal as a string, got %s", data)
+	}
+
+	var decoded NullULID
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Error unmarshaling NullULID: %v", err)
+	}
+	if !decoded.Valid || !decoded.ULID.IsZero() {
+		t.Errorf("Round trip mismatch: got %+v", decoded)
+	}
+}
@@ -0,0 +1,69 @@
+package ulid
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"math/bits"
+)
+
+// Uint128 returns the ULID as a 128-bit unsigned integer split into its high
+// and low 64 bits. Numeric order matches ULID order.
+func (u ULID) Uint128() (hi, lo uint64) {
+	data := u.bytes()
+	return binary.BigEndian.Uint64(data[:8]), binary.BigEndian.Uint64(data[8:])
+}
+
+// FromUint128 builds the ULID whose 128-bit value is hi<<64 | lo.
+func FromUint128(hi, lo uint64) ULID {
+	var data [totalBytes]byte
+	binary.BigEndian.PutUint64(data[:8], hi)
+	binary.BigEndian.PutUint64(data[8:], lo)
+	return fromBytes(data)
+}
+
+// BigInt returns the ULID as a non-negative 128-bit integer.
+func (u ULID) BigInt() *big.Int {
+	data := u.bytes()
+	return new(big.Int).SetBytes(data[:])
+}
+
+// FromBigInt builds the ULID with the given numeric value. It returns an
+// error if n is negative or does not fit in 128 bits.
+func FromBigInt(n *big.Int) (ULID, error) {
+	if n.Sign() < 0 {
+		return ULID{}, errors.New("ULID value must not be negative")
+	}
+	if n.BitLen() > 8*totalBytes {
+		return ULID{}, errors.New("ULID value exceeds 128 bits")
+	}
+
+	var data [totalBytes]byte
+	n.FillBytes(data[:])
+	return fromBytes(data), nil
+}
+
+// Next returns the ULID immediately following u in sort order, carrying from
+// the randomness into the timestamp. It returns an error if u is Max. Next is
+// useful for turning an inclusive key range bound into an exclusive one.
+func (u ULID) Next() (ULID, error) {
+	hi, lo := u.Uint128()
+	lo, carry := bits.Add64(lo, 1, 0)
+	hi, carry = bits.Add64(hi, 0, carry)
+	if carry != 0 {
+		return ULID{}, errors.New("ULID overflow: no successor of the maximum ULID")
+	}
+	return FromUint128(hi, lo), nil
+}
+
+// Prev returns the ULID immediately preceding u in sort order, borrowing from
+// the timestamp when the randomness is zero. It returns an error if u is Zero.
+func (u ULID) Prev() (ULID, error) {
+	hi, lo := u.Uint128()
+	lo, borrow := bits.Sub64(lo, 1, 0)
+	hi, borrow = bits.Sub64(hi, 0, borrow)
+	if borrow != 0 {
+		return ULID{}, errors.New("ULID underflow: no predecessor of the zero ULID")
+	}
+	return FromUint128(hi, lo), nil
+}
@@ -0,0 +1,74 @@
+package ulid
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestUint128(t *testing.T) {
+	u := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+
+	hi, lo := u.Uint128()
+	if hi != 0x0186e56d70000102 || lo != 0x030405060708090a {
+		t.Errorf("Uint128 mismatch: got %#x %#x", hi, lo)
+	}
+	if back := FromUint128(hi, lo); back != u {
+		t.Errorf("Round trip failed: got %s, expected %s", back, u)
+	}
+
+	if hi, lo := Max.Uint128(); hi != ^uint64(0) || lo != ^uint64(0) {
+		t.Errorf("Expected Max to be all ones, got %#x %#x", hi, lo)
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	u := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+
+	n := u.BigInt()
+	want, _ := new(big.Int).SetString("0186e56d70000102030405060708090a", 16)
+	if n.Cmp(want) != 0 {
+		t.Errorf("BigInt mismatch: got %x", n)
+	}
+
+	back, err := FromBigInt(n)
+	if err != nil {
+		t.Fatalf("Error converting big.Int: %v", err)
+	}
+	if back != u {
+		t.Errorf("Round trip failed: got %s, expected %s", back, u)
+	}
+
+	if _, err := FromBigInt(big.NewInt(-1)); err == nil {
+		t.Errorf("Expected error for negative value")
+	}
+	if _, err := FromBigInt(new(big.Int).Lsh(big.NewInt(1), 128)); err == nil {
+		t.Errorf("Expected error for value beyond 128 bits")
+	}
+}
+
+func TestNextPrev(t *testing.T) {
+	u := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}}
+
+	next, err := u.Next()
+	if err != nil {
+		t.Fatalf("Error computing Next: %v", err)
+	}
+	if next.GetTime() != u.GetTime()+1 || next.Entropy() != ([randomnessBytes]byte{}) {
+		t.Errorf("Expected carry into the timestamp, got %s", next.DebugString())
+	}
+
+	prev, err := next.Prev()
+	if err != nil {
+		t.Fatalf("Error computing Prev: %v", err)
+	}
+	if prev != u {
+		t.Errorf("Prev mismatch: got %s, expected %s", prev, u)
+	}
+
+	if _, err := Max.Next(); err == nil {
+		t.Errorf("Expected error for Max.Next")
+	}
+	if _, err := Zero.Prev(); err == nil {
+		t.Errorf("Expected error for Zero.Prev")
+	}
+}
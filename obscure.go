@@ -0,0 +1,30 @@
+package ulid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+)
+
+// ObscureTime returns a copy of u whose timestamp reveals only the
+// granularity-sized bucket in which it was created: the timestamp is rounded
+// down to a multiple of granularity and a random offset within the bucket is
+// added back. IDs from different buckets keep their relative order, while
+// the precise creation time within a bucket is hidden. The randomness
+// component is left unchanged. Granularities of one millisecond or less
+// return u as is.
+func (u ULID) ObscureTime(granularity time.Duration) (ULID, error) {
+	ms := uint64(granularity / time.Millisecond)
+	if ms <= 1 {
+		return u, nil
+	}
+
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return ULID{}, err
+	}
+
+	start := u.timestamp - u.timestamp%ms
+	u.timestamp = min(start+binary.BigEndian.Uint64(buf[:])%ms, maxTimestamp)
+	return u, nil
+}
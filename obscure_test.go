@@ -0,0 +1,40 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestObscureTime(t *testing.T) {
+	ts := time.Date(2024, 3, 15, 13, 47, 12, 345e6, time.UTC)
+	u := ULID{timestamp: uint64(ts.UnixMilli()), randomness: [randomnessBytes]byte{1, 2, 3}}
+
+	hourStart := ts.Truncate(time.Hour)
+	varied := false
+	for range 20 {
+		obscured, err := u.ObscureTime(time.Hour)
+		if err != nil {
+			t.Fatalf("Error obscuring time: %v", err)
+		}
+		if obscured.Time().Before(hourStart) || !obscured.Time().Before(hourStart.Add(time.Hour)) {
+			t.Fatalf("Obscured time %v outside the hour bucket", obscured.Time())
+		}
+		if obscured.Entropy() != u.Entropy() {
+			t.Errorf("Expected randomness to be preserved")
+		}
+		if obscured.GetTime() != u.GetTime() {
+			varied = true
+		}
+	}
+	if !varied {
+		t.Errorf("Expected the timestamp to be re-randomized within the bucket")
+	}
+
+	same, err := u.ObscureTime(time.Millisecond)
+	if err != nil {
+		t.Fatalf("Error obscuring time: %v", err)
+	}
+	if same != u {
+		t.Errorf("Expected millisecond granularity to leave the ULID unchanged")
+	}
+}
@@ -0,0 +1,82 @@
+package ulid
+
+import "strings"
+
+// NewOption adjusts a single call to New.
+type NewOption func(*newConfig)
+
+type newConfig struct {
+	timestamp    uint64
+	hasTimestamp bool
+	entropy      []byte
+	uppercase    bool
+}
+
+// WithTimestamp pins the timestamp in milliseconds, as with NewTime.
+func WithTimestamp(timestamp uint64) NewOption {
+	return func(c *newConfig) {
+		c.timestamp = timestamp
+		c.hasTimestamp = true
+	}
+}
+
+// WithEntropyBytes supplies the 10-byte randomness component explicitly, e.g.
+// derived from an idempotency key so that a retried request produces the same
+// ID when combined with WithTimestamp. The timestamp is read from the default
+// generator's clock and epoch, but the ID does not advance its monotonic
+// state; New returns an error if entropy is not 10 bytes long.
+func WithEntropyBytes(entropy []byte) NewOption {
+	return func(c *newConfig) {
+		c.entropy = entropy
+	}
+}
+
+// WithUppercase makes New return the ID in upper case, regardless of
+// SetUppercase. It applies on top of the default generator's WithEncoding.
+func WithUppercase() NewOption {
+	return func(c *newConfig) {
+		c.uppercase = true
+	}
+}
+
+// newWithOptions implements New for a non-empty option list
+func newWithOptions(opts []NewOption) (string, error) {
+	var cfg newConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	g := Default()
+	var u ULID
+	var err error
+	switch {
+	case cfg.entropy != nil:
+		if len(cfg.entropy) != randomnessBytes {
+			return "", lengthError(randomnessBytes, len(cfg.entropy))
+		}
+		if cfg.hasTimestamp {
+			if cfg.timestamp > maxTimestamp {
+				return "", ErrTimestampOverflow
+			}
+			if u.timestamp, err = g.sinceEpoch(cfg.timestamp); err != nil {
+				return "", err
+			}
+		} else if u.timestamp = g.clockTimestamp(); u.timestamp > maxTimestamp {
+			return "", ErrTimestampOverflow
+		}
+		copy(u.randomness[:], cfg.entropy)
+	case cfg.hasTimestamp:
+		u, err = g.NewULIDTime(cfg.timestamp)
+	default:
+		u, err = g.NewULID()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	s := g.encode(&u)
+	if cfg.uppercase {
+		return strings.ToUpper(s), nil
+	}
+	return s, nil
+}
@@ -0,0 +1,121 @@
+package ulid
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewOptions(t *testing.T) {
+	timestamp := uint64(1678886400000)
+	entropy := bytes.Repeat([]byte{0x5a}, randomnessBytes)
+
+	a, err := New(WithTimestamp(timestamp), WithEntropyBytes(entropy))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	b, err := New(WithTimestamp(timestamp), WithEntropyBytes(entropy))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if a != b {
+		t.Errorf("Expected identical IDs for identical inputs, got %s and %s", a, b)
+	}
+
+	parsed, err := Parse(a)
+	if err != nil {
+		t.Fatalf("Error parsing ULID: %v", err)
+	}
+	if parsed.GetTime() != timestamp {
+		t.Errorf("Timestamp mismatch: got %d, expected %d", parsed.GetTime(), timestamp)
+	}
+	if got := parsed.Entropy(); !bytes.Equal(got[:], entropy) {
+		t.Errorf("Entropy mismatch: got %x, expected %x", got, entropy)
+	}
+
+	upper, err := New(WithTimestamp(timestamp), WithUppercase())
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if upper != strings.ToUpper(upper) {
+		t.Errorf("Expected upper case output, got %s", upper)
+	}
+	if parsed, _ := Parse(upper); parsed.GetTime() != timestamp {
+		t.Errorf("Timestamp mismatch: got %d, expected %d", parsed.GetTime(), timestamp)
+	}
+}
+
+func TestNewOptionsErrors(t *testing.T) {
+	if _, err := New(WithEntropyBytes(make([]byte, 4))); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Expected ErrInvalidLength for short entropy, got %v", err)
+	}
+	if _, err := New(WithTimestamp(maxTimestamp + 1)); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Expected ErrTimestampOverflow, got %v", err)
+	}
+	if _, err := New(WithTimestamp(maxTimestamp+1), WithEntropyBytes(make([]byte, randomnessBytes))); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Expected ErrTimestampOverflow with explicit entropy, got %v", err)
+	}
+}
+
+func TestNewOptionsEntropyBytesUsesDefaultClock(t *testing.T) {
+	previous := Default()
+	defer SetDefault(previous)
+
+	epoch := time.UnixMilli(1600000000000)
+	now := time.UnixMilli(1678886400000)
+	SetDefault(NewGenerator(WithEpoch(epoch), WithClock(func() time.Time { return now })))
+	if _, err := NewULID(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+
+	// A regressed clock is held at the last reading, as for generated IDs
+	now = now.Add(-time.Second)
+	id, err := New(WithEntropyBytes(make([]byte, randomnessBytes)))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	parsed, err := Parse(id)
+	if err != nil {
+		t.Fatalf("Error parsing ULID: %v", err)
+	}
+	if want := uint64(1678886400000 - 1600000000000); parsed.GetTime() != want {
+		t.Errorf("Timestamp mismatch: got %d, expected %d", parsed.GetTime(), want)
+	}
+
+	id, err = New(WithTimestamp(1678886400000), WithEntropyBytes(make([]byte, randomnessBytes)))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if parsed, _ := Parse(id); parsed.GetTime() != 1678886400000-1600000000000 {
+		t.Errorf("Expected the pinned timestamp relative to the epoch, got %d", parsed.GetTime())
+	}
+}
+
+func TestNewOptionsUseDefaultEncoding(t *testing.T) {
+	previous := Default()
+	defer SetDefault(previous)
+	SetDefault(NewGenerator(WithEncoding(ZBase32)))
+
+	timestamp := uint64(1678886400000)
+	id, err := New(WithTimestamp(timestamp))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	parsed, err := ZBase32.Decode(id)
+	if err != nil {
+		t.Fatalf("Expected a z-base-32 ID, got %s: %v", id, err)
+	}
+	if parsed.GetTime() != timestamp {
+		t.Errorf("Timestamp mismatch: got %d, expected %d", parsed.GetTime(), timestamp)
+	}
+
+	id, err = New(WithEntropyBytes(make([]byte, randomnessBytes)), WithUppercase())
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if _, err := ZBase32.Decode(strings.ToLower(id)); err != nil || id != strings.ToUpper(id) {
+		t.Errorf("Expected an upper case z-base-32 ID, got %s: %v", id, err)
+	}
+}
@@ -0,0 +1,119 @@
+package ulid
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// OverflowPolicy determines what a Generator does when the randomness of a
+// millisecond is exhausted, i.e. when incrementing the previous ID's
+// randomness to keep IDs monotonic would wrap around.
+type OverflowPolicy int
+
+const (
+	// OverflowBumpTimestamp moves the ID into the next millisecond with fresh
+	// randomness. IDs stay strictly increasing, but the embedded timestamp may
+	// run ahead of the clock. ErrEntropyExhausted is returned at the maximum
+	// timestamp. This is the default.
+	OverflowBumpTimestamp OverflowPolicy = iota
+
+	// OverflowError fails the call with ErrMonotonicOverflow. IDs stay strictly
+	// increasing and timestamps are never altered; generation in the same
+	// millisecond keeps failing until the timestamp advances.
+	OverflowError
+
+	// OverflowWaitForNextMillisecond blocks until the clock reaches the next
+	// millisecond and generates the ID there. IDs stay strictly increasing and
+	// timestamps never run ahead of the clock, at the cost of latency. IDs with
+	// an explicit timestamp (NewTime) cannot wait and fail with
+	// ErrMonotonicOverflow. If the exhausted millisecond is already ahead of
+	// the generator's clock, e.g. held there after a clock regression, the
+	// call fails with ErrEntropyExhausted rather than waiting for the clock to
+	// catch up.
+	OverflowWaitForNextMillisecond
+
+	// OverflowFreshRandom keeps the timestamp and draws fresh randomness,
+	// from which subsequent IDs in the millisecond continue incrementing. IDs
+	// remain unique with overwhelming probability, but the ID after an
+	// overflow may sort before its predecessors in the same millisecond;
+	// ordering across milliseconds is unaffected.
+	OverflowFreshRandom
+)
+
+// String returns the name of the policy.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBumpTimestamp:
+		return "bump-timestamp"
+	case OverflowError:
+		return "error"
+	case OverflowWaitForNextMillisecond:
+		return "wait-for-next-millisecond"
+	case OverflowFreshRandom:
+		return "fresh-random"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// WithOverflowPolicy sets how the Generator handles randomness overflow
+// within a millisecond. The default is OverflowBumpTimestamp.
+func WithOverflowPolicy(policy OverflowPolicy) GeneratorOption {
+	return func(g *Generator) {
+		g.overflowPolicy = policy
+	}
+}
+
+// overflow resolves a randomness overflow at timestamp according to the
+// overflow policy, returning the timestamp to continue at with fresh
+// randomness. When wait is set the caller must wait for the clock to pass
+// timestamp and generate again.
+func (g *Generator) overflow(timestamp uint64, useClock bool) (uint64, bool, error) {
+	switch g.overflowPolicy {
+	case OverflowError:
+		return 0, false, ErrMonotonicOverflow
+	case OverflowWaitForNextMillisecond:
+		if !useClock {
+			return 0, false, ErrMonotonicOverflow
+		}
+		return timestamp, true, nil
+	case OverflowFreshRandom:
+		return timestamp, false, nil
+	default:
+		timestamp++
+		if timestamp > maxTimestamp {
+			return 0, false, ErrEntropyExhausted
+		}
+		return timestamp, false, nil
+	}
+}
+
+// waitPast blocks until the generator's clock reads a millisecond after
+// timestamp, or until ctx is done. The generator's clock includes the epoch
+// and, under ClockRegressionMonotonic, the monotonic advance. If timestamp is
+// ahead of that clock, e.g. held by ClockRegressionClamp or a persisted
+// high-water mark after a clock regression, waiting could last as long as
+// the regression, so it fails with ErrEntropyExhausted instead.
+func (g *Generator) waitPast(ctx context.Context, timestamp uint64) error {
+	for {
+		now := g.now()
+		if g.clockPolicy == ClockRegressionMonotonic {
+			now, _ = monotonicClock(g.state.Load().clockAnchor, now)
+		}
+		switch ms := g.millis(now); {
+		case ms > timestamp:
+			return nil
+		case ms < timestamp:
+			return ErrEntropyExhausted
+		}
+
+		timer := time.NewTimer(time.Millisecond - time.Duration(now.UnixNano()%int64(time.Millisecond)))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package ulid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// exhaustedGenerator returns a generator whose randomness at timestamp is
+// exhausted
+func exhaustedGenerator(timestamp uint64, opts ...GeneratorOption) *Generator {
+	g := NewGenerator(opts...)
+	state := &generatorState{lastTime: timestamp, burst: 1}
+	for i := range state.lastRandomness {
+		state.lastRandomness[i] = 0xFF
+	}
+	g.state.Store(state)
+	return g
+}
+
+func TestOverflowPolicies(t *testing.T) {
+	timestamp := uint64(time.Now().UnixMilli())
+
+	tests := []struct {
+		policy   OverflowPolicy
+		wantTime uint64
+		wantErr  error
+	}{
+		{OverflowBumpTimestamp, timestamp + 1, nil},
+		{OverflowError, 0, ErrMonotonicOverflow},
+		{OverflowWaitForNextMillisecond, 0, ErrMonotonicOverflow},
+		{OverflowFreshRandom, timestamp, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			g := exhaustedGenerator(timestamp, WithOverflowPolicy(tt.policy))
+			u, err := g.NewULIDTime(timestamp)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Error mismatch: got %v, expected %v", err, tt.wantErr)
+			}
+			if err == nil && u.GetTime() != tt.wantTime {
+				t.Errorf("Timestamp mismatch: got %d, expected %d", u.GetTime(), tt.wantTime)
+			}
+			if got := g.Stats().Overflows; got != 1 {
+				t.Errorf("Overflows mismatch: got %d, expected 1", got)
+			}
+		})
+	}
+}
+
+func TestOverflowWaitForNextMillisecond(t *testing.T) {
+	base := time.Now().Truncate(time.Millisecond)
+	calls := 0
+	clock := func() time.Time {
+		calls++
+		if calls == 1 {
+			return base
+		}
+		return base.Add(time.Millisecond)
+	}
+
+	g := exhaustedGenerator(uint64(base.UnixMilli()),
+		WithClock(clock), WithOverflowPolicy(OverflowWaitForNextMillisecond))
+	u, err := g.NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if want := uint64(base.UnixMilli()) + 1; u.GetTime() != want {
+		t.Errorf("Timestamp mismatch: got %d, expected %d", u.GetTime(), want)
+	}
+}
+
+func TestOverflowWaitAheadOfClock(t *testing.T) {
+	base := time.Now()
+	ahead := uint64(base.Add(time.Hour).UnixMilli())
+
+	// The exhausted millisecond is an hour ahead, e.g. held there after a
+	// clock regression; waiting for the clock would block for an hour
+	g := exhaustedGenerator(ahead,
+		WithClock(func() time.Time { return base }),
+		WithClockRegressionPolicy(ClockRegressionClamp),
+		WithOverflowPolicy(OverflowWaitForNextMillisecond))
+
+	start := time.Now()
+	if _, err := g.NewULID(); !errors.Is(err, ErrEntropyExhausted) {
+		t.Errorf("Expected ErrEntropyExhausted, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the call to fail fast, took %v", elapsed)
+	}
+}
+
+func TestOverflowPolicyString(t *testing.T) {
+	if got := OverflowPolicy(42).String(); got != "OverflowPolicy(42)" {
+		t.Errorf("String mismatch: got %q", got)
+	}
+}
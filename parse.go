@@ -0,0 +1,276 @@
+package ulid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// strictDecodeTable accepts only the canonical alphabet, in either case
+var strictDecodeTable [256]byte
+
+func init() {
+	for i := range strictDecodeTable {
+		strictDecodeTable[i] = 0xFF
+	}
+	for i := range len(crockfordAlphabet) {
+		c := crockfordAlphabet[i]
+		strictDecodeTable[c] = byte(i)
+		strictDecodeTable[lowerToUpper(c)] = byte(i)
+	}
+}
+
+// CaseMode restricts the letter case accepted by ParseWithOptions.
+type CaseMode int
+
+const (
+	// CaseInsensitive accepts letters in any case, like Parse.
+	CaseInsensitive CaseMode = iota
+
+	// CaseLower accepts only lower-case letters, the form produced by String.
+	CaseLower
+
+	// CaseUpper accepts only upper-case letters, the form used by the ULID
+	// specification.
+	CaseUpper
+
+	// CaseConsistent accepts either case but rejects strings mixing both.
+	CaseConsistent
+)
+
+// ParseOptions configures the additional validation performed by ParseWithOptions.
+// The zero value performs no checks beyond those of Parse.
+type ParseOptions struct {
+	// Strict accepts only canonical strings: characters of the canonical
+	// alphabet, rejecting the Crockford aliases I, L, O and U that Parse maps
+	// to 1, 1, 0 and V, and a last character whose two padding bits are
+	// non-zero. The 26 characters carry 130 bits; the encoding stores the 128
+	// ULID bits first, so the final character holds 3 data bits followed by
+	// 2 padding bits that Parse silently discards.
+	Strict bool
+
+	// Lenient accepts display forms copied by humans: surrounding
+	// whitespace is trimmed, and hyphens, underscores and spaces used to
+	// group characters are removed before decoding.
+	Lenient bool
+
+	// Case restricts the letter case of the input, so that systems using
+	// ULIDs as case-sensitive keys can reject mixed-case duplicates.
+	Case CaseMode
+
+	// MaxFutureSkew rejects ULIDs whose embedded timestamp is more than
+	// MaxFutureSkew ahead of the current time. Zero disables the check.
+	MaxFutureSkew time.Duration
+
+	// NotBefore rejects ULIDs whose embedded timestamp is earlier than
+	// NotBefore, e.g. before the service launched. Zero disables the check.
+	NotBefore time.Time
+
+	// NotAfter rejects ULIDs whose embedded timestamp is later than
+	// NotAfter. Zero disables the check.
+	NotAfter time.Time
+
+	// Now returns the reference time used for timestamp checks.
+	// Defaults to time.Now when nil.
+	Now func() time.Time
+}
+
+// now returns the reference time for timestamp checks
+func (o ParseOptions) now() time.Time {
+	if o.Now != nil {
+		return o.Now()
+	}
+	return time.Now()
+}
+
+// ParseWithOptions parses a ULID string like Parse, applying the validation
+// rules configured in opts.
+func ParseWithOptions(s string, opts ParseOptions) (ULID, error) {
+	if opts.Lenient {
+		s = stripSeparators(s)
+	}
+
+	if err := checkCase(s, opts.Case); err != nil {
+		return ULID{}, err
+	}
+
+	table := &decodeTable
+	if opts.Strict {
+		table = &strictDecodeTable
+	}
+
+	data, err := ultraFastDecode(table, s)
+	if err != nil {
+		return ULID{}, err
+	}
+	if opts.Strict && table[s[encodedLength-1]]&0x03 != 0 {
+		return ULID{}, fmt.Errorf("%w: last character %q sets padding bits", ErrOverflow, s[encodedLength-1])
+	}
+	u := fromBytes(data)
+
+	if opts.MaxFutureSkew > 0 {
+		limit := opts.now().Add(opts.MaxFutureSkew).UnixMilli()
+		if limit < 0 || u.timestamp > uint64(limit) {
+			return ULID{}, fmt.Errorf("%w: too far in the future", ErrTimestampRejected)
+		}
+	}
+	if !opts.NotBefore.IsZero() && int64(u.timestamp) < opts.NotBefore.UnixMilli() {
+		return ULID{}, fmt.Errorf("%w: before %s", ErrTimestampRejected, opts.NotBefore.UTC().Format(time.RFC3339Nano))
+	}
+	if !opts.NotAfter.IsZero() && int64(u.timestamp) > opts.NotAfter.UnixMilli() {
+		return ULID{}, fmt.Errorf("%w: after %s", ErrTimestampRejected, opts.NotAfter.UTC().Format(time.RFC3339Nano))
+	}
+
+	return u, nil
+}
+
+// ParseStrict parses a ULID string that must use only the canonical alphabet.
+// It is shorthand for ParseWithOptions with Strict set, for validating IDs
+// that will be used verbatim, e.g. as storage keys.
+func ParseStrict(s string) (ULID, error) {
+	return ParseWithOptions(s, ParseOptions{Strict: true})
+}
+
+// IsValid reports whether s would be accepted by Parse, checking only the
+// length and alphabet. It does not allocate or decode the value.
+func IsValid(s string) bool {
+	if len(s) != encodedLength {
+		return false
+	}
+	for i := range encodedLength {
+		if decodeTable[s[i]] == 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseMany parses every string in ss, appending the ULIDs to dst so that a
+// buffer can be reused across batches. Entries that fail to parse are
+// appended as the zero ULID, keeping the result aligned with ss, and the
+// returned error joins one *IndexError per failure. Use errors.As to find
+// the first failing entry.
+func ParseMany(dst []ULID, ss []string) ([]ULID, error) {
+	dst = slices.Grow(dst, len(ss))
+
+	var errs []error
+	for i, s := range ss {
+		data, err := ultraFastDecode(&decodeTable, s)
+		if err != nil {
+			errs = append(errs, &IndexError{Index: i, Err: err})
+			dst = append(dst, ULID{})
+			continue
+		}
+		dst = append(dst, fromBytes(data))
+	}
+	return dst, errors.Join(errs...)
+}
+
+// ParseLenient parses a ULID that may be wrapped in whitespace or grouped with
+// separators, such as "01arz-3ndek-tsv4r-rffq6-9g5fa-r". It is shorthand for
+// ParseWithOptions with Lenient set.
+func ParseLenient(s string) (ULID, error) {
+	return ParseWithOptions(s, ParseOptions{Lenient: true})
+}
+
+// stripSeparators removes whitespace and grouping separators from s
+func stripSeparators(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.ContainsAny(s, "-_ ") {
+		return s
+	}
+
+	b := make([]byte, 0, len(s))
+	for i := range len(s) {
+		switch s[i] {
+		case '-', '_', ' ':
+		default:
+			b = append(b, s[i])
+		}
+	}
+	return string(b)
+}
+
+// ParseTime returns the timestamp embedded in a ULID string as a UTC time,
+// decoding only the first 10 characters. The randomness characters are not
+// validated; use Parse when the whole string must be checked.
+func ParseTime(s string) (time.Time, error) {
+	if len(s) != encodedLength {
+		return time.Time{}, lengthError(encodedLength, len(s))
+	}
+
+	// The first 10 characters carry 50 bits: the 48-bit timestamp followed
+	// by the 2 most significant randomness bits.
+	var acc uint64
+	for i := range 10 {
+		v := decodeTable[s[i]]
+		if v == 0xFF {
+			return time.Time{}, &InvalidCharacterError{Pos: i, Char: s[i]}
+		}
+		acc = acc<<5 | uint64(v)
+	}
+	return time.UnixMilli(int64(acc >> 2)).UTC(), nil
+}
+
+// DecodeInto parses s like Parse and stores the result in *dst, for tight
+// loops that fill preallocated slices. On error *dst is left unchanged.
+func DecodeInto(dst *ULID, s string) error {
+	data, err := ultraFastDecode(&decodeTable, s)
+	if err != nil {
+		return err
+	}
+	*dst = fromBytes(data)
+	return nil
+}
+
+// checkCase returns an *InvalidCharacterError for the first letter of s
+// that violates mode
+func checkCase(s string, mode CaseMode) error {
+	if mode == CaseInsensitive {
+		return nil
+	}
+
+	var seen byte // case of the first letter under CaseConsistent
+	for i := range len(s) {
+		c := s[i]
+		var upper bool
+		switch {
+		case 'a' <= c && c <= 'z':
+		case 'A' <= c && c <= 'Z':
+			upper = true
+		default:
+			continue
+		}
+
+		ok := true
+		switch mode {
+		case CaseLower:
+			ok = !upper
+		case CaseUpper:
+			ok = upper
+		case CaseConsistent:
+			current := byte('l')
+			if upper {
+				current = 'u'
+			}
+			if seen == 0 {
+				seen = current
+			}
+			ok = current == seen
+		}
+		if !ok {
+			return &InvalidCharacterError{Pos: i, Char: c}
+		}
+	}
+	return nil
+}
+
+// ParseInRange parses a ULID string and rejects it with ErrTimestampRejected
+// unless its embedded timestamp lies within [earliest, latest] at millisecond
+// precision. It is shorthand for ParseWithOptions with NotBefore and NotAfter
+// set, a cheap defense against forged identifiers.
+func ParseInRange(s string, earliest, latest time.Time) (ULID, error) {
+	return ParseWithOptions(s, ParseOptions{NotBefore: earliest, NotAfter: latest})
+}
@@ -0,0 +1,307 @@
+package ulid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseWithOptionsMaxFutureSkew(t *testing.T) {
+	now := time.Now()
+	opts := ParseOptions{
+		MaxFutureSkew: time.Minute,
+		Now:           func() time.Time { return now },
+	}
+
+	recent, err := NewTime(uint64(now.Add(30 * time.Second).UnixMilli()))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if _, err := ParseWithOptions(recent, opts); err != nil {
+		t.Errorf("Expected ULID within skew to parse, got error: %v", err)
+	}
+
+	future, err := NewTime(uint64(now.Add(2 * time.Minute).UnixMilli()))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if _, err := ParseWithOptions(future, opts); err == nil {
+		t.Errorf("Expected error for ULID beyond max future skew")
+	}
+
+	if _, err := ParseWithOptions(future, ParseOptions{}); err != nil {
+		t.Errorf("Expected zero options to accept any valid ULID, got error: %v", err)
+	}
+
+	if _, err := ParseWithOptions("invalid-ulid-string", opts); err == nil {
+		t.Errorf("Expected error for invalid ULID string")
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	canonical := "01arz3ndektsv4rrffq69g5far"
+
+	for _, s := range []string{canonical, "01ARZ3NDEKTSV4RRFFQ69G5FAR"} {
+		u, err := ParseStrict(s)
+		if err != nil {
+			t.Fatalf("Error parsing %s: %v", s, err)
+		}
+		if u.String() != canonical {
+			t.Errorf("ParseStrict mismatch: got %s, expected %s", u, canonical)
+		}
+	}
+
+	for _, s := range []string{"0iarz3ndektsv4rrffq69g5far", "0Larz3ndektsv4rrffq69g5far", "o1arz3ndektsv4rrffq69g5far", "01arz3ndektsu4rrffq69g5far"} {
+		if _, err := Parse(s); err != nil {
+			t.Errorf("Expected Parse to accept alias in %s, got %v", s, err)
+		}
+		if _, err := ParseStrict(s); err == nil {
+			t.Errorf("Expected ParseStrict to reject alias in %s", s)
+		}
+	}
+}
+
+func TestParseStrictPaddingBits(t *testing.T) {
+	// The last character carries 3 data bits and 2 padding bits, so only
+	// characters whose value is a multiple of 4 are canonical there.
+	for i, c := range []byte(crockfordAlphabet) {
+		s := "01arz3ndektsv4rrffq69g5fa" + string(c)
+		_, err := ParseStrict(s)
+		if i%4 == 0 && err != nil {
+			t.Errorf("Expected %s to be accepted, got %v", s, err)
+		}
+		if i%4 != 0 && !errors.Is(err, ErrOverflow) {
+			t.Errorf("Expected ErrOverflow for %s, got %v", s, err)
+		}
+		if _, err := Parse(s); err != nil {
+			t.Errorf("Expected Parse to accept %s, got %v", s, err)
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	tests := map[string]bool{
+		"01arz3ndektsv4rrffq69g5far":  true,
+		"01ARZ3NDEKTSV4RRFFQ69G5FAV":  true,
+		"0iarz3ndektsv4rrffq69g5far":  true,
+		"01arz3ndektsv4rrffq69g5fa":   false,
+		"01arz3ndektsv4rrffq69g5farr": false,
+		"01arz3ndektsv4rrffq69g5fa!":  false,
+		"":                            false,
+	}
+	for s, want := range tests {
+		if got := IsValid(s); got != want {
+			t.Errorf("IsValid(%q) = %v, expected %v", s, got, want)
+		}
+		if _, err := Parse(s); (err == nil) != want {
+			t.Errorf("IsValid(%q) disagrees with Parse: %v", s, err)
+		}
+	}
+}
+
+func BenchmarkIsValid(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsValid("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	}
+}
+
+func TestParseMany(t *testing.T) {
+	var ss []string
+	for range 10 {
+		ulidStr, err := New()
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		ss = append(ss, ulidStr)
+	}
+
+	buf := make([]ULID, 0, 16)
+	ids, err := ParseMany(buf, ss)
+	if err != nil {
+		t.Fatalf("Error parsing ULIDs: %v", err)
+	}
+	if len(ids) != len(ss) || &ids[0] != &buf[:1][0] {
+		t.Fatalf("Expected %d ULIDs in the reused buffer, got %d", len(ss), len(ids))
+	}
+	for i, u := range ids {
+		if u.String() != ss[i] {
+			t.Errorf("ParseMany mismatch at %d: got %s, expected %s", i, u, ss[i])
+		}
+	}
+
+	ss[3], ss[7] = "invalid", ss[7][:25]+"!"
+	ids, err = ParseMany(ids[:0], ss)
+	if len(ids) != len(ss) || !ids[3].IsZero() || !ids[7].IsZero() {
+		t.Errorf("Expected failing entries to be zero and aligned")
+	}
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) || indexErr.Index != 3 {
+		t.Fatalf("Expected first IndexError at 3, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidLength) || !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("Expected joined errors to match both causes, got %v", err)
+	}
+}
+
+func BenchmarkParseMany(b *testing.B) {
+	ss := make([]string, 1024)
+	for i := range ss {
+		ss[i], _ = New()
+	}
+	buf := make([]ULID, 0, len(ss))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, _ = ParseMany(buf[:0], ss)
+	}
+}
+
+func TestParseLenient(t *testing.T) {
+	want := MustParse("01arz3ndektsv4rrffq69g5far")
+
+	inputs := []string{
+		"01arz3ndektsv4rrffq69g5far",
+		"  01ARZ3NDEKTSV4RRFFQ69G5FAR\n",
+		"01arz-3ndek-tsv4r-rffq6-9g5fa-r",
+		"01ar z3nd ekts v4rr ffq6 9g5f ar",
+		"01arz3nd_ektsv4rr_ffq69g5far",
+	}
+	for _, s := range inputs {
+		got, err := ParseLenient(s)
+		if err != nil {
+			t.Fatalf("Error parsing %q: %v", s, err)
+		}
+		if got != want {
+			t.Errorf("ParseLenient(%q) = %s, expected %s", s, got, want)
+		}
+	}
+
+	if _, err := Parse("01arz-3ndek-tsv4r-rffq6-9g5fa-r"); err == nil {
+		t.Errorf("Expected Parse to reject grouped input")
+	}
+	if _, err := ParseLenient("01arz-3ndek-tsv4r-rffq6-9g5fa-r-x"); err == nil {
+		t.Errorf("Expected error for too many characters")
+	}
+	if _, err := ParseWithOptions(" 0iarz3ndektsv4rrffq69g5far ", ParseOptions{Lenient: true, Strict: true}); err == nil {
+		t.Errorf("Expected strict check to apply after stripping")
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	for range 100 {
+		u, err := NewULID()
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		got, err := ParseTime(u.String())
+		if err != nil {
+			t.Fatalf("Error parsing time: %v", err)
+		}
+		if !got.Equal(u.Time()) {
+			t.Errorf("ParseTime mismatch: got %v, expected %v", got, u.Time())
+		}
+	}
+
+	if got, _ := ParseTime(Max.String()); !got.Equal(MaxTime()) {
+		t.Errorf("ParseTime mismatch for Max: got %v", got)
+	}
+	if _, err := ParseTime("01arz3ndek"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Expected ErrInvalidLength, got %v", err)
+	}
+	if _, err := ParseTime("01arz3nde!tsv4rrffq69g5far"); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("Expected ErrInvalidCharacter, got %v", err)
+	}
+}
+
+func BenchmarkParseTime(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseTime("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	}
+}
+
+func TestDecodeInto(t *testing.T) {
+	want := MustParse("01arz3ndektsv4rrffq69g5far")
+
+	ids := make([]ULID, 2)
+	if err := DecodeInto(&ids[1], "01arz3ndektsv4rrffq69g5far"); err != nil {
+		t.Fatalf("Error decoding ULID: %v", err)
+	}
+	if ids[1] != want {
+		t.Errorf("DecodeInto mismatch: got %s, expected %s", ids[1], want)
+	}
+
+	if err := DecodeInto(&ids[1], "invalid"); err == nil {
+		t.Errorf("Expected error for invalid input")
+	}
+	if ids[1] != want {
+		t.Errorf("Expected destination to be unchanged on error, got %s", ids[1])
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() {
+		_ = DecodeInto(&ids[0], "01arz3ndektsv4rrffq69g5far")
+	}); allocs != 0 {
+		t.Errorf("Expected DecodeInto not to allocate, got %v allocations", allocs)
+	}
+}
+
+func TestParseWithOptionsCase(t *testing.T) {
+	lower := "01arz3ndektsv4rrffq69g5far"
+	upper := "01ARZ3NDEKTSV4RRFFQ69G5FAR"
+	mixed := "01arz3ndektsv4rrffq69G5far"
+
+	tests := []struct {
+		mode                CaseMode
+		lower, upper, mixed bool
+	}{
+		{CaseInsensitive, true, true, true},
+		{CaseLower, true, false, false},
+		{CaseUpper, false, true, false},
+		{CaseConsistent, true, true, false},
+	}
+	for _, tt := range tests {
+		opts := ParseOptions{Case: tt.mode}
+		for s, want := range map[string]bool{lower: tt.lower, upper: tt.upper, mixed: tt.mixed} {
+			_, err := ParseWithOptions(s, opts)
+			if (err == nil) != want {
+				t.Errorf("Case mode %d, input %s: got error %v, expected accept=%v", tt.mode, s, err, want)
+			}
+		}
+	}
+
+	_, err := ParseWithOptions(mixed, ParseOptions{Case: CaseConsistent})
+	var charErr *InvalidCharacterError
+	if !errors.As(err, &charErr) || charErr.Pos != 21 || charErr.Char != 'G' {
+		t.Errorf("Expected error pointing at the mixed-case letter, got %v", err)
+	}
+}
+
+func TestParseInRange(t *testing.T) {
+	launch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := launch.Add(24 * time.Hour)
+
+	inside := ULID{timestamp: uint64(launch.Add(time.Hour).UnixMilli())}
+	if _, err := ParseInRange(inside.String(), launch, end); err != nil {
+		t.Errorf("Expected ULID inside the window to parse, got %v", err)
+	}
+	for _, bound := range []time.Time{launch, end} {
+		u := ULID{timestamp: uint64(bound.UnixMilli())}
+		if _, err := ParseInRange(u.String(), launch, end); err != nil {
+			t.Errorf("Expected inclusive bound %v, got %v", bound, err)
+		}
+	}
+
+	before := ULID{timestamp: uint64(launch.UnixMilli()) - 1}
+	if _, err := ParseInRange(before.String(), launch, end); !errors.Is(err, ErrTimestampRejected) {
+		t.Errorf("Expected ErrTimestampRejected before the window, got %v", err)
+	}
+	after := ULID{timestamp: uint64(end.UnixMilli()) + 1}
+	if _, err := ParseInRange(after.String(), launch, end); !errors.Is(err, ErrTimestampRejected) {
+		t.Errorf("Expected ErrTimestampRejected after the window, got %v", err)
+	}
+
+	if _, err := ParseWithOptions(before.String(), ParseOptions{NotAfter: end}); err != nil {
+		t.Errorf("Expected open lower bound, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package ulid
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// uint80 holds an 80-bit randomness value as high (16 bits) and low words
+type uint80 struct {
+	hi, lo uint64
+}
+
+func uint80FromBig(b *big.Int) uint80 {
+	var buf [randomnessBytes]byte
+	b.FillBytes(buf[:])
+	return uint80FromBytes(buf)
+}
+
+func uint80FromBytes(b [randomnessBytes]byte) uint80 {
+	return uint80{
+		hi: uint64(b[0])<<8 | uint64(b[1]),
+		lo: uint64(b[2])<<56 | uint64(b[3])<<48 | uint64(b[4])<<40 | uint64(b[5])<<32 |
+			uint64(b[6])<<24 | uint64(b[7])<<16 | uint64(b[8])<<8 | uint64(b[9]),
+	}
+}
+
+func (v uint80) bytes() [randomnessBytes]byte {
+	return [randomnessBytes]byte{
+		byte(v.hi >> 8), byte(v.hi),
+		byte(v.lo >> 56), byte(v.lo >> 48), byte(v.lo >> 40), byte(v.lo >> 32),
+		byte(v.lo >> 24), byte(v.lo >> 16), byte(v.lo >> 8), byte(v.lo),
+	}
+}
+
+func (v uint80) add(w uint80) uint80 {
+	lo, carry := bits.Add64(v.lo, w.lo, 0)
+	return uint80{hi: v.hi + w.hi + carry, lo: lo}
+}
+
+func (v uint80) less(w uint80) bool {
+	return v.hi < w.hi || (v.hi == w.hi && v.lo < w.lo)
+}
+
+// mask keeps the low n bits of v
+func (v uint80) mask(n int) uint80 {
+	switch {
+	case n <= 0:
+		return uint80{}
+	case n < 64:
+		return uint80{lo: v.lo & (1<<n - 1)}
+	default:
+		return uint80{hi: v.hi & (1<<(n-64) - 1), lo: v.lo}
+	}
+}
+
+// EntropyPartition generates monotonic ULIDs whose randomness component lies
+// in a slice of the 80-bit space that is disjoint from every other partition
+// returned by the same PartitionEntropy call. Workers that each own a
+// partition can generate in parallel without coordination and without any
+// chance of producing the same ID.
+//
+// Within a millisecond, IDs start at a random offset in the lower half of the
+// slice and are incremented from there, leaving headroom for bursts. When a
+// slice is exhausted the timestamp is moved forward by one millisecond, and
+// later IDs with an earlier timestamp keep the last ID's timestamp.
+type EntropyPartition struct {
+	start, end uint80 // [start, end)
+	offsetBits int
+
+	mu       sync.Mutex
+	lastTime uint64
+	last     uint80
+	started  bool
+}
+
+// PartitionEntropy splits the 80-bit randomness space into n disjoint,
+// equally sized partitions.
+func PartitionEntropy(n int) ([]*EntropyPartition, error) {
+	if n < 1 {
+		return nil, errors.New("partition count must be at least 1")
+	}
+
+	space := new(big.Int).Lsh(big.NewInt(1), randomnessBits)
+	size := new(big.Int).Div(space, big.NewInt(int64(n)))
+	if size.Sign() == 0 {
+		return nil, errors.New("too many partitions")
+	}
+
+	partitions := make([]*EntropyPartition, n)
+	for i := range partitions {
+		start := new(big.Int).Mul(size, big.NewInt(int64(i)))
+		end := new(big.Int).Add(start, size)
+		if i == n-1 {
+			end = new(big.Int).Sub(space, big.NewInt(1)) // exclusive bound must fit in 80 bits
+		}
+		partitions[i] = &EntropyPartition{
+			start:      uint80FromBig(start),
+			end:        uint80FromBig(end),
+			offsetBits: size.BitLen() - 2, // offsets stay below size/2
+		}
+	}
+
+	return partitions, nil
+}
+
+// New returns a new ULID from this partition using the current time.
+func (p *EntropyPartition) New() (string, error) {
+	return p.NewTime(uint64(timeNow().UnixMilli()))
+}
+
+// NewTime returns a new ULID from this partition with the given timestamp in milliseconds.
+func (p *EntropyPartition) NewTime(timestamp uint64) (string, error) {
+	u, err := p.newULIDTime(timestamp)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// newULIDTime generates the next ULID of the partition at timestamp
+func (p *EntropyPartition) newULIDTime(timestamp uint64) (ULID, error) {
+	if timestamp > maxTimestamp {
+		return ULID{}, ErrTimestampOverflow
+	}
+
+	randomness, err := generateRandomness()
+	if err != nil {
+		return ULID{}, err
+	}
+	offset := uint80FromBytes(randomness).mask(p.offsetBits)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.started {
+		// Never go back, e.g. behind a millisecond an exhausted slice moved to
+		timestamp = max(timestamp, p.lastTime)
+	}
+	value := p.start.add(offset)
+	if p.started && timestamp == p.lastTime {
+		value = p.last.add(uint80{lo: 1})
+		if !value.less(p.end) {
+			// Slice exhausted - move to the next millisecond
+			timestamp++
+			if timestamp > maxTimestamp {
+				return ULID{}, ErrEntropyExhausted
+			}
+			value = p.start.add(offset)
+		}
+	}
+
+	p.started = true
+	p.lastTime = timestamp
+	p.last = value
+
+	return ULID{timestamp: timestamp, randomness: value.bytes()}, nil
+}
@@ -0,0 +1,136 @@
+package ulid
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+)
+
+func TestPartitionEntropyDisjoint(t *testing.T) {
+	partitions, err := PartitionEntropy(3)
+	if err != nil {
+		t.Fatalf("Error partitioning entropy: %v", err)
+	}
+
+	for i := 1; i < len(partitions); i++ {
+		if partitions[i].start != partitions[i-1].end {
+			t.Errorf("Partition %d does not start where partition %d ends", i, i-1)
+		}
+	}
+	if partitions[0].start != (uint80{}) {
+		t.Errorf("First partition does not start at zero")
+	}
+
+	timestamp := uint64(1678886400000)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[string]bool)
+
+	for i, p := range partitions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			previous := ""
+			for range 1000 {
+				id, err := p.NewTime(timestamp)
+				if err != nil {
+					t.Errorf("Error generating ULID: %v", err)
+					return
+				}
+				if id <= previous {
+					t.Errorf("Partition %d not monotonic: %s <= %s", i, id, previous)
+					return
+				}
+				previous = id
+
+				parsed, _ := Parse(id)
+				value := uint80FromBytes(parsed.randomness)
+				if value.less(p.start) || !value.less(p.end) {
+					t.Errorf("Partition %d produced randomness outside its slice", i)
+					return
+				}
+
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("Duplicate ULID %s", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPartitionEntropyExhaustion(t *testing.T) {
+	partitions, err := PartitionEntropy(1)
+	if err != nil {
+		t.Fatalf("Error partitioning entropy: %v", err)
+	}
+	p := partitions[0]
+
+	p.started = true
+	p.lastTime = 1000
+	p.last = uint80{hi: p.end.hi, lo: p.end.lo - 1}
+
+	id, err := p.NewTime(1000)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	parsed, _ := Parse(id)
+	if parsed.GetTime() != 1001 {
+		t.Errorf("Expected exhausted slice to advance the timestamp, got %d", parsed.GetTime())
+	}
+
+	next, err := p.NewTime(1000)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if next <= id {
+		t.Errorf("Expected the clock millisecond after a bump to sort later: %s <= %s", next, id)
+	}
+
+	if _, err := PartitionEntropy(0); err == nil {
+		t.Errorf("Expected error for zero partitions")
+	}
+}
+
+func TestPartitionEntropyOffsetHeadroom(t *testing.T) {
+	for _, n := range []int{1, 2, 3} {
+		partitions, err := PartitionEntropy(n)
+		if err != nil {
+			t.Fatalf("Error partitioning entropy: %v", err)
+		}
+		space := new(big.Int).Lsh(big.NewInt(1), randomnessBits)
+		size := new(big.Int).Div(space, big.NewInt(int64(n)))
+		half := new(big.Int).Rsh(size, 1)
+
+		for i, p := range partitions {
+			start := new(big.Int).Mul(size, big.NewInt(int64(i)))
+			limit := uint80FromBig(new(big.Int).Add(start, half))
+			for timestamp := range uint64(200) {
+				id, err := p.NewTime(timestamp)
+				if err != nil {
+					t.Fatalf("Error generating ULID: %v", err)
+				}
+				parsed, _ := Parse(id)
+				if value := uint80FromBytes(parsed.randomness); !value.less(limit) {
+					t.Fatalf("n=%d: partition %d started at %v, not below start + size/2", n, i, value)
+				}
+			}
+		}
+	}
+}
+
+func TestUint80(t *testing.T) {
+	v := uint80{hi: 0x1234, lo: 0xFFFFFFFFFFFFFFFF}
+	if got := uint80FromBytes(v.bytes()); got != v {
+		t.Errorf("Byte round trip mismatch: got %v, expected %v", got, v)
+	}
+	if got := v.add(uint80{lo: 1}); got != (uint80{hi: 0x1235}) {
+		t.Errorf("Carry mismatch: got %v", got)
+	}
+	if got := v.mask(64); got != (uint80{lo: v.lo}) {
+		t.Errorf("Mask mismatch: got %v", got)
+	}
+}
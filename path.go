@@ -0,0 +1,186 @@
+package ulid
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultPathTemplate partitions object keys by UTC year, month, day and hour.
+const DefaultPathTemplate = "{yyyy}/{MM}/{dd}/{HH}/{ulid}"
+
+// pathField identifies a placeholder in a PathTemplate
+type pathField int
+
+const (
+	pathLiteral pathField = iota
+	pathYear
+	pathMonth
+	pathDay
+	pathHour
+	pathMinute
+	pathULID
+)
+
+// pathPlaceholders maps placeholder names to fields
+var pathPlaceholders = map[string]pathField{
+	"{yyyy}": pathYear,
+	"{MM}":   pathMonth,
+	"{dd}":   pathDay,
+	"{HH}":   pathHour,
+	"{mm}":   pathMinute,
+	"{ulid}": pathULID,
+}
+
+// pathToken is a literal or placeholder of a template, or a parsed value
+type pathToken struct {
+	field   pathField
+	literal string
+	value   int
+}
+
+// PathTemplate renders ULIDs into time-partitioned storage paths such as
+// "events/2024/06/30/13/<ulid>" and parses such paths back. Time placeholders
+// are rendered from the ULID's embedded timestamp in UTC.
+//
+// Supported placeholders are {yyyy}, {MM}, {dd}, {HH}, {mm} and {ulid}; the
+// template must contain {ulid} exactly once.
+type PathTemplate struct {
+	tokens []pathToken
+}
+
+// NewPathTemplate compiles a path template.
+func NewPathTemplate(template string) (*PathTemplate, error) {
+	t := &PathTemplate{}
+	ulids := 0
+
+	for rest := template; rest != ""; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			t.tokens = append(t.tokens, pathToken{literal: rest})
+			break
+		}
+		if start > 0 {
+			t.tokens = append(t.tokens, pathToken{literal: rest[:start]})
+		}
+
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in path template %q", template)
+		}
+		name := rest[start : start+end+1]
+		field, ok := pathPlaceholders[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown placeholder %s in path template", name)
+		}
+		if field == pathULID {
+			ulids++
+		}
+		t.tokens = append(t.tokens, pathToken{field: field})
+		rest = rest[start+end+1:]
+	}
+
+	if ulids != 1 {
+		return nil, errors.New("path template must contain {ulid} exactly once")
+	}
+
+	return t, nil
+}
+
+// Render returns the storage path for u.
+func (t *PathTemplate) Render(u ULID) string {
+	ts := u.Time()
+
+	var b strings.Builder
+	for _, tok := range t.tokens {
+		switch tok.field {
+		case pathLiteral:
+			b.WriteString(tok.literal)
+		case pathYear:
+			fmt.Fprintf(&b, "%04d", ts.Year())
+		case pathMonth:
+			fmt.Fprintf(&b, "%02d", int(ts.Month()))
+		case pathDay:
+			fmt.Fprintf(&b, "%02d", ts.Day())
+		case pathHour:
+			fmt.Fprintf(&b, "%02d", ts.Hour())
+		case pathMinute:
+			fmt.Fprintf(&b, "%02d", ts.Minute())
+		case pathULID:
+			b.WriteString(u.String())
+		}
+	}
+	return b.String()
+}
+
+// Parse extracts the ULID from a path rendered by Render. It returns an error
+// if the path does not match the template or if its time partitions disagree
+// with the ULID's embedded timestamp.
+func (t *PathTemplate) Parse(path string) (ULID, error) {
+	var u ULID
+	var parts []pathToken
+
+	rest := path
+	for _, tok := range t.tokens {
+		if tok.field == pathLiteral {
+			if !strings.HasPrefix(rest, tok.literal) {
+				return ULID{}, fmt.Errorf("path %q does not match template", path)
+			}
+			rest = rest[len(tok.literal):]
+			continue
+		}
+
+		width := 2
+		switch tok.field {
+		case pathYear:
+			width = 4
+		case pathULID:
+			width = encodedLength
+		}
+		if len(rest) < width {
+			return ULID{}, fmt.Errorf("path %q does not match template", path)
+		}
+
+		if tok.field == pathULID {
+			parsed, err := Parse(rest[:width])
+			if err != nil {
+				return ULID{}, err
+			}
+			u = parsed
+		} else {
+			value, err := strconv.Atoi(rest[:width])
+			if err != nil {
+				return ULID{}, fmt.Errorf("path %q does not match template", path)
+			}
+			parts = append(parts, pathToken{field: tok.field, value: value})
+		}
+		rest = rest[width:]
+	}
+
+	if rest != "" {
+		return ULID{}, fmt.Errorf("path %q does not match template", path)
+	}
+
+	ts := u.Time()
+	for _, p := range parts {
+		var want int
+		switch p.field {
+		case pathYear:
+			want = ts.Year()
+		case pathMonth:
+			want = int(ts.Month())
+		case pathDay:
+			want = ts.Day()
+		case pathHour:
+			want = ts.Hour()
+		case pathMinute:
+			want = ts.Minute()
+		}
+		if p.value != want {
+			return ULID{}, fmt.Errorf("path %q time partition does not match the ULID timestamp", path)
+		}
+	}
+
+	return u, nil
+}
@@ -0,0 +1,57 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPathTemplate(t *testing.T) {
+	tmpl, err := NewPathTemplate("events/" + DefaultPathTemplate + ".json")
+	if err != nil {
+		t.Fatalf("Error compiling template: %v", err)
+	}
+
+	ts := time.Date(2024, 6, 30, 13, 45, 0, 0, time.UTC)
+	ulidStr, err := NewTime(uint64(ts.UnixMilli()))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	u, _ := Parse(ulidStr)
+
+	path := tmpl.Render(u)
+	if want := "events/2024/06/30/13/" + ulidStr + ".json"; path != want {
+		t.Errorf("Render mismatch: got %s, expected %s", path, want)
+	}
+
+	parsed, err := tmpl.Parse(path)
+	if err != nil {
+		t.Fatalf("Error parsing path: %v", err)
+	}
+	if parsed != u {
+		t.Errorf("Parse mismatch: got %v, expected %v", parsed, u)
+	}
+
+	for _, bad := range []string{
+		"events/2024/06/30/14/" + ulidStr + ".json", // wrong hour
+		"logs/2024/06/30/13/" + ulidStr + ".json",   // wrong prefix
+		"events/2024/06/30/13/" + ulidStr,           // missing suffix
+		"events/2024/06/30/13/short.json",
+	} {
+		if _, err := tmpl.Parse(bad); err == nil {
+			t.Errorf("Expected error parsing %q", bad)
+		}
+	}
+}
+
+func TestNewPathTemplateInvalid(t *testing.T) {
+	for _, tmpl := range []string{
+		"{yyyy}/{MM}",   // no ulid
+		"{ulid}/{ulid}", // duplicate ulid
+		"{yyyy}/{unknown}/{ulid}",
+		"{yyyy/{ulid}",
+	} {
+		if _, err := NewPathTemplate(tmpl); err == nil {
+			t.Errorf("Expected error for template %q", tmpl)
+		}
+	}
+}
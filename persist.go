@@ -0,0 +1,154 @@
+package ulid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StateStore persists a Generator's timestamp high-water mark, so that IDs
+// generated after a restart sort after every ID issued before it.
+type StateStore interface {
+	// Load returns the last saved high-water mark in milliseconds, or 0 if
+	// none has been saved.
+	Load() (uint64, error)
+
+	// Save durably records a new high-water mark in milliseconds.
+	Save(timestamp uint64) error
+}
+
+// persistence tracks the high-water mark of a Generator with a StateStore
+type persistence struct {
+	store    StateStore
+	interval uint64 // milliseconds reserved ahead by each save
+
+	loadOnce sync.Once
+	loadErr  error
+
+	mu      sync.Mutex
+	ceiling uint64 // saved high-water mark; issued IDs have earlier timestamps
+}
+
+// WithStateStore makes the Generator monotonic across restarts and clock
+// regressions. Before issuing an ID whose timestamp reaches the saved
+// high-water mark, the Generator saves a new mark interval ahead of it, so
+// the store is written at most once per interval under steady load. On first
+// use the Generator loads the mark and never issues timestamps below it.
+//
+// While a store is configured, a wall clock that moves backwards no longer
+// produces IDs that sort before earlier ones: the timestamp is held at the
+// last issued millisecond until the clock catches up. Explicit timestamps
+// passed to NewTime are used as given. A longer interval means fewer writes,
+// but after a restart timestamps run ahead of the clock until it reaches
+// the mark. An interval below one millisecond is treated as one millisecond.
+//
+// If loading or saving fails, generation returns the error rather than risk
+// issuing out-of-order IDs.
+func WithStateStore(store StateStore, interval time.Duration) GeneratorOption {
+	return func(g *Generator) {
+		g.persistence = &persistence{
+			store:    store,
+			interval: max(uint64(interval.Milliseconds()), 1),
+		}
+	}
+}
+
+// load restores the high-water mark into the generator state once
+func (p *persistence) load(g *Generator) error {
+	p.loadOnce.Do(func() {
+		ceiling, err := p.store.Load()
+		if err != nil {
+			p.loadErr = err
+			return
+		}
+		p.ceiling = ceiling
+		if ceiling > 0 {
+			g.state.Store(&generatorState{lastTime: ceiling})
+		}
+	})
+	return p.loadErr
+}
+
+// reserve saves a new high-water mark if timestamp has reached the current one
+func (p *persistence) reserve(timestamp uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if timestamp < p.ceiling {
+		return nil
+	}
+
+	ceiling := min(timestamp+p.interval, maxTimestamp+1)
+	if err := p.store.Save(ceiling); err != nil {
+		return err
+	}
+	p.ceiling = ceiling
+	return nil
+}
+
+// FileStateStore is a StateStore keeping the high-water mark in a file as a
+// decimal number of milliseconds. Saves replace the file atomically.
+type FileStateStore struct {
+	Path string
+}
+
+// Load reads the high-water mark, returning 0 if the file does not exist.
+func (s FileStateStore) Load() (uint64, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// Save writes the high-water mark to a temporary file, syncs it, renames it
+// over the state file and syncs the directory so that the rename survives a
+// crash.
+func (s FileStateStore) Save(timestamp uint64) error {
+	dir := filepath.Dir(s.Path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(strconv.FormatUint(timestamp, 10) + "\n"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.Path); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir flushes a directory entry change such as a rename to disk. Windows
+// cannot sync directories, and persists renames on its own.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
+}
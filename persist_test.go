@@ -0,0 +1,112 @@
+package ulid
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// memoryStateStore is an in-memory StateStore counting saves
+type memoryStateStore struct {
+	ceiling uint64
+	saves   int
+	err     error
+}
+
+func (s *memoryStateStore) Load() (uint64, error) {
+	return s.ceiling, s.err
+}
+
+func (s *memoryStateStore) Save(timestamp uint64) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.ceiling = timestamp
+	s.saves++
+	return nil
+}
+
+func TestWithStateStoreRestart(t *testing.T) {
+	store := &memoryStateStore{}
+	current := time.Now()
+	clock := func() time.Time { return current }
+
+	g := NewGenerator(WithClock(clock), WithStateStore(store, time.Second))
+	var last ULID
+	for range 100 {
+		u, err := g.NewULID()
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		last = u
+	}
+	if store.saves != 1 {
+		t.Errorf("Expected a single save within the interval, got %d", store.saves)
+	}
+
+	// Restart with the clock moved backwards
+	current = current.Add(-time.Minute)
+	g = NewGenerator(WithClock(clock), WithStateStore(store, time.Second))
+	previous := last
+	for range 100 {
+		u, err := g.NewULID()
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		if u.Compare(previous) <= 0 {
+			t.Fatalf("ID after restart sorts before an earlier one: %s <= %s", u, previous)
+		}
+		previous = u
+	}
+}
+
+func TestWithStateStoreClockBackwards(t *testing.T) {
+	current := time.Now()
+	g := NewGenerator(WithClock(func() time.Time { return current }),
+		WithStateStore(&memoryStateStore{}, time.Millisecond))
+
+	first, err := g.NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	current = current.Add(-time.Second)
+	second, err := g.NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if second.Compare(first) <= 0 {
+		t.Errorf("Expected the ID after a clock regression to sort last: %s <= %s", second, first)
+	}
+}
+
+func TestWithStateStoreErrors(t *testing.T) {
+	failure := errors.New("store unavailable")
+	g := NewGenerator(WithStateStore(&memoryStateStore{err: failure}, time.Second))
+	if _, err := g.New(); !errors.Is(err, failure) {
+		t.Errorf("Expected the store error, got %v", err)
+	}
+}
+
+func TestFileStateStore(t *testing.T) {
+	store := FileStateStore{Path: filepath.Join(t.TempDir(), "ulid.state")}
+
+	ceiling, err := store.Load()
+	if err != nil || ceiling != 0 {
+		t.Fatalf("Expected 0 from a missing file, got %d, %v", ceiling, err)
+	}
+	if err := store.Save(1678886400000); err != nil {
+		t.Fatalf("Error saving state: %v", err)
+	}
+	ceiling, err = store.Load()
+	if err != nil || ceiling != 1678886400000 {
+		t.Errorf("Round trip mismatch: got %d, %v", ceiling, err)
+	}
+
+	if err := syncDir(filepath.Dir(store.Path)); err != nil {
+		t.Errorf("Error syncing the state directory: %v", err)
+	}
+	if err := syncDir(filepath.Join(filepath.Dir(store.Path), "missing")); err == nil {
+		t.Errorf("Expected an error syncing a missing directory")
+	}
+}
@@ -0,0 +1,35 @@
+package ulid
+
+// Pool keeps up to size pre-generated ULID strings ready for latency-critical
+// paths. It is a Buffer that refills to full capacity whenever fewer than low
+// IDs remain, for callers that only want to pick a size and a watermark.
+type Pool struct {
+	buffer *Buffer
+}
+
+// NewPool creates a Pool of size IDs that refills once it drops below low;
+// 0 <= low < size must hold. Call Close to stop the refill goroutine.
+func NewPool(size, low int) (*Pool, error) {
+	buffer, err := NewBuffer(size, low, size)
+	if err != nil {
+		return nil, err
+	}
+	return &Pool{buffer: buffer}, nil
+}
+
+// Get returns a pre-generated ID, or a freshly generated one when the pool is
+// empty.
+func (p *Pool) Get() (string, error) {
+	return p.buffer.Get()
+}
+
+// Len returns the number of IDs currently in the pool.
+func (p *Pool) Len() int {
+	return p.buffer.Len()
+}
+
+// Close stops the refill goroutine and waits for it to exit. Get keeps
+// working after Close, generating IDs synchronously once the pool drains.
+func (p *Pool) Close() {
+	p.buffer.Close()
+}
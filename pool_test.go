@@ -0,0 +1,49 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPool(t *testing.T) {
+	if _, err := NewPool(0, 0); err == nil {
+		t.Errorf("Expected error for zero size")
+	}
+	if _, err := NewPool(8, 8); err == nil {
+		t.Errorf("Expected error for low watermark not below size")
+	}
+
+	pool, err := NewPool(32, 8)
+	if err != nil {
+		t.Fatalf("Error creating pool: %v", err)
+	}
+	defer pool.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for pool.Len() < 32 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := pool.Len(); got != 32 {
+		t.Fatalf("Expected pool to fill to its size, got %d", got)
+	}
+
+	seen := make(map[string]bool)
+	for range 100 {
+		ulidStr, err := pool.Get()
+		if err != nil {
+			t.Fatalf("Error getting ULID: %v", err)
+		}
+		if _, err := Parse(ulidStr); err != nil {
+			t.Fatalf("Pooled ULID %q does not parse: %v", ulidStr, err)
+		}
+		if seen[ulidStr] {
+			t.Fatalf("Duplicate ULID from pool: %s", ulidStr)
+		}
+		seen[ulidStr] = true
+	}
+
+	pool.Close()
+	if _, err := pool.Get(); err != nil {
+		t.Errorf("Expected Get to keep working after Close, got %v", err)
+	}
+}
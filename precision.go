@@ -0,0 +1,57 @@
+package ulid
+
+import "time"
+
+// subMillisecondBits is the width of the sub-millisecond fraction stored in
+// the top bits of the randomness component
+const subMillisecondBits = 10
+
+// WithSubMillisecondPrecision makes the Generator store the time within the
+// millisecond, in units of 1/1024 ms (about 977 ns), in the top 10 bits of
+// the randomness component. IDs generated from the clock in the same
+// millisecond then sort by their sub-millisecond time rather than only by
+// issue order, and PreciseTime recovers the finer timestamp. The layout and
+// string form are unchanged, so the IDs remain valid ULIDs that sort with
+// standard ones at millisecond granularity.
+//
+// The remaining 70 bits are random; IDs sharing a fraction are incremented
+// as usual, which may carry into the fraction. IDs with an explicit
+// timestamp (NewTime) get a zero fraction. The option cannot be combined with
+// WithNode, which reserves the same bits; NewGenerator panics if both are
+// set.
+func WithSubMillisecondPrecision() GeneratorOption {
+	return func(g *Generator) {
+		g.subMillisecond = true
+	}
+}
+
+// PreciseTime returns the embedded timestamp including the sub-millisecond
+// fraction stored by a generator configured with WithSubMillisecondPrecision.
+// For other ULIDs the result is Time plus a random offset below one
+// millisecond.
+func (u ULID) PreciseTime() time.Time {
+	return u.Time().Add(fractionDuration(fraction(&u.randomness)))
+}
+
+// clockFraction returns the position of now within its millisecond in units
+// of 1/1024 ms
+func clockFraction(now time.Time) uint16 {
+	nanos := now.UnixNano() % int64(time.Millisecond)
+	return uint16(nanos << subMillisecondBits / int64(time.Millisecond))
+}
+
+// fractionDuration converts a fraction in units of 1/1024 ms to a duration
+func fractionDuration(frac uint16) time.Duration {
+	return time.Duration(frac) * time.Millisecond >> subMillisecondBits
+}
+
+// fraction reads the sub-millisecond fraction from the top of randomness
+func fraction(randomness *[randomnessBytes]byte) uint16 {
+	return uint16(randomness[0])<<2 | uint16(randomness[1]>>6)
+}
+
+// setFraction stores frac in the top bits of randomness
+func setFraction(randomness *[randomnessBytes]byte, frac uint16) {
+	randomness[0] = byte(frac >> 2)
+	randomness[1] = randomness[1]&0x3f | byte(frac<<6)
+}
@@ -0,0 +1,70 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithSubMillisecondPrecision(t *testing.T) {
+	base := time.UnixMilli(time.Now().UnixMilli())
+	current := base.Add(900 * time.Microsecond)
+	g := NewGenerator(WithSubMillisecondPrecision(), WithClock(func() time.Time { return current }))
+
+	late, err := g.NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if d := late.PreciseTime().Sub(current); d > 0 || d <= -time.Microsecond {
+		t.Errorf("PreciseTime mismatch: got %v, expected about %v", late.PreciseTime(), current)
+	}
+	if !g.Time(late).Equal(late.PreciseTime()) {
+		t.Errorf("Generator.Time mismatch: got %v, expected %v", g.Time(late), late.PreciseTime())
+	}
+
+	// Within the same millisecond, IDs remain monotonic even if the clock
+	// reads an earlier fraction
+	current = base.Add(100 * time.Microsecond)
+	next, err := g.NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if next.Compare(late) <= 0 {
+		t.Errorf("Expected %s to sort after %s", next, late)
+	}
+
+	u, err := g.NewULIDTime(uint64(base.UnixMilli()) + 1)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if fraction(&u.randomness) != 0 {
+		t.Errorf("Expected a zero fraction for an explicit timestamp, got %d", fraction(&u.randomness))
+	}
+}
+
+func TestSubMillisecondOrdering(t *testing.T) {
+	base := time.UnixMilli(time.Now().UnixMilli())
+	current := base
+	g := NewGenerator(WithSubMillisecondPrecision(), WithClock(func() time.Time { return current }))
+
+	var prev ULID
+	for i := range 10 {
+		current = base.Add(time.Duration(i) * 100 * time.Microsecond)
+		u, err := g.NewULID()
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		if i > 0 && !u.PreciseTime().After(prev.PreciseTime()) {
+			t.Errorf("Expected %v after %v", u.PreciseTime(), prev.PreciseTime())
+		}
+		prev = u
+	}
+}
+
+func TestSubMillisecondPrecisionWithNodePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic combining WithSubMillisecondPrecision and WithNode")
+		}
+	}()
+	NewGenerator(WithSubMillisecondPrecision(), WithNode(1, 8))
+}
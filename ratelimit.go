@@ -0,0 +1,133 @@
+package ulid
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimitMode selects how a rate-limited Generator behaves once its limit
+// is reached.
+type RateLimitMode int
+
+const (
+	// RateLimitReject fails generation with a *RateLimitError.
+	RateLimitReject RateLimitMode = iota
+
+	// RateLimitWait blocks until the ID can be issued, or until the context
+	// passed to NewContext is done.
+	RateLimitWait
+)
+
+// String returns the name of the mode.
+func (m RateLimitMode) String() string {
+	switch m {
+	case RateLimitReject:
+		return "reject"
+	case RateLimitWait:
+		return "wait"
+	default:
+		return fmt.Sprintf("RateLimitMode(%d)", int(m))
+	}
+}
+
+// rateLimiter is a token bucket holding up to capacity tokens and refilled at
+// a constant rate
+type rateLimiter struct {
+	mode     RateLimitMode
+	capacity float64
+	interval time.Duration // time to refill one token
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// WithRateLimit caps the Generator at n IDs per period, e.g. when IDs map one
+// to one to billable downstream operations. Up to n IDs can be issued in a
+// burst; the allowance then refills evenly over the period. The mode selects
+// whether exceeding the limit fails with a *RateLimitError or blocks. Every
+// generation method of the Generator counts against the limit.
+//
+// WithRateLimit panics if n or period is not positive.
+func WithRateLimit(n int, period time.Duration, mode RateLimitMode) GeneratorOption {
+	if n < 1 || period <= 0 {
+		panic("ulid: rate limit must be positive")
+	}
+	return func(g *Generator) {
+		g.limiter = &rateLimiter{
+			mode:     mode,
+			capacity: float64(n),
+			interval: period / time.Duration(n),
+			tokens:   float64(n),
+		}
+	}
+}
+
+// reserve takes a token at now, returning how long the caller must wait
+// before using it. In RateLimitReject mode no token is taken when one is not
+// available immediately.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens = min(l.tokens+float64(now.Sub(l.last))/float64(l.interval), l.capacity)
+	}
+	if now.After(l.last) {
+		l.last = now
+	}
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	delay := time.Duration((1 - l.tokens) * float64(l.interval))
+	if l.mode == RateLimitWait {
+		l.tokens--
+	}
+	return delay
+}
+
+// cancel returns a token reserved by a waiter that gave up
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	l.tokens = min(l.tokens+1, l.capacity)
+	l.mu.Unlock()
+}
+
+// take applies the rate limit without blocking, returning errWouldBlock
+// where RateLimitWait mode would wait
+func (l *rateLimiter) take(now time.Time) error {
+	delay := l.reserve(now)
+	if delay == 0 {
+		return nil
+	}
+	if l.mode == RateLimitWait {
+		l.cancel()
+		return errWouldBlock
+	}
+	return &RateLimitError{RetryAfter: delay}
+}
+
+// wait applies the rate limit before generating an ID
+func (l *rateLimiter) wait(ctx context.Context, now time.Time) error {
+	delay := l.reserve(now)
+	if delay == 0 {
+		return nil
+	}
+	if l.mode != RateLimitWait {
+		return &RateLimitError{RetryAfter: delay}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,67 @@
+package ulid
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimitReject(t *testing.T) {
+	current := time.Now()
+	g := NewGenerator(WithClock(func() time.Time { return current }),
+		WithRateLimit(3, time.Second, RateLimitReject))
+
+	for range 3 {
+		if _, err := g.New(); err != nil {
+			t.Fatalf("Error generating ULID within the limit: %v", err)
+		}
+	}
+
+	_, err := g.New()
+	var rateErr *RateLimitError
+	if !errors.Is(err, ErrRateLimited) || !errors.As(err, &rateErr) {
+		t.Fatalf("Expected a *RateLimitError, got %v", err)
+	}
+	if rateErr.RetryAfter <= 0 || rateErr.RetryAfter > time.Second/3 {
+		t.Errorf("Unexpected RetryAfter: %v", rateErr.RetryAfter)
+	}
+
+	// One token is refilled after a third of the period
+	current = current.Add(time.Second / 3)
+	if _, err := g.New(); err != nil {
+		t.Errorf("Error generating ULID after refill: %v", err)
+	}
+	if _, err := g.New(); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+}
+
+func TestWithRateLimitWait(t *testing.T) {
+	g := NewGenerator(WithRateLimit(1, 20*time.Millisecond, RateLimitWait))
+
+	start := time.Now()
+	for range 3 {
+		if _, err := g.New(); err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("Expected generation to be paced, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := g.NewContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded while waiting, got %v", err)
+	}
+}
+
+func TestWithRateLimitInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for a zero rate limit")
+		}
+	}()
+	WithRateLimit(0, time.Second, RateLimitReject)
+}
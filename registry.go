@@ -0,0 +1,50 @@
+package ulid
+
+import (
+	"errors"
+	"sync"
+)
+
+// IDGenerator is implemented by the independently configured ULID
+// generators in this package, such as *Generator, *ShardedGenerator,
+// *Encoding and *EntropyPartition.
+type IDGenerator interface {
+	// New returns a new ULID string using the current time.
+	New() (string, error)
+
+	// NewTime returns a new ULID string with the given timestamp in milliseconds.
+	NewTime(timestamp uint64) (string, error)
+}
+
+var registry sync.Map // map[string]IDGenerator
+
+// Register makes gen available under name, so multi-tenant applications can
+// look up per-tenant generators with Lookup instead of passing them through
+// every layer. It returns an error if name is empty, gen is nil, or name is
+// already registered.
+func Register(name string, gen IDGenerator) error {
+	if name == "" {
+		return errors.New("generator name must not be empty")
+	}
+	if gen == nil {
+		return errors.New("generator must not be nil")
+	}
+	if _, loaded := registry.LoadOrStore(name, gen); loaded {
+		return errors.New("generator already registered: " + name)
+	}
+	return nil
+}
+
+// Lookup returns the generator registered under name.
+func Lookup(name string) (IDGenerator, bool) {
+	gen, ok := registry.Load(name)
+	if !ok {
+		return nil, false
+	}
+	return gen.(IDGenerator), true
+}
+
+// Unregister removes the generator registered under name, if any.
+func Unregister(name string) {
+	registry.Delete(name)
+}
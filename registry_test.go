@@ -0,0 +1,57 @@
+package ulid
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	partitions, err := PartitionEntropy(2)
+	if err != nil {
+		t.Fatalf("Error partitioning entropy: %v", err)
+	}
+	defer Unregister("tenant-a")
+	defer Unregister("tenant-b")
+
+	if err := Register("tenant-a", partitions[0]); err != nil {
+		t.Fatalf("Error registering generator: %v", err)
+	}
+	if err := Register("tenant-b", partitions[1]); err != nil {
+		t.Fatalf("Error registering generator: %v", err)
+	}
+	if err := Register("tenant-a", partitions[1]); err == nil {
+		t.Errorf("Expected error registering a duplicate name")
+	}
+	if err := Register("", partitions[0]); err == nil {
+		t.Errorf("Expected error registering an empty name")
+	}
+	if err := Register("tenant-c", nil); err == nil {
+		t.Errorf("Expected error registering a nil generator")
+	}
+
+	gen, ok := Lookup("tenant-a")
+	if !ok || gen != IDGenerator(partitions[0]) {
+		t.Fatalf("Lookup returned the wrong generator")
+	}
+	if _, ok := Lookup("unknown"); ok {
+		t.Errorf("Expected lookup of an unregistered name to fail")
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gen, _ := Lookup("tenant-b")
+			if _, err := gen.New(); err != nil {
+				t.Errorf("Error generating ULID: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	Unregister("tenant-a")
+	if _, ok := Lookup("tenant-a"); ok {
+		t.Errorf("Expected generator to be unregistered")
+	}
+}
@@ -0,0 +1,39 @@
+package ulid
+
+// NewWithSequence returns a new ULID together with its sequence number within
+// its millisecond: 0 for the first ID generated in a millisecond, then 1, 2,
+// ... for IDs that share it. This makes same-millisecond bursts visible to
+// event pipelines.
+func NewWithSequence() (string, uint64, error) {
+	return Default().NewWithSequence()
+}
+
+// NewTimeWithSequence is like NewWithSequence but uses the given timestamp in
+// milliseconds.
+func NewTimeWithSequence(timestamp uint64) (string, uint64, error) {
+	return Default().NewTimeWithSequence(timestamp)
+}
+
+// NewWithSequence is like New but also returns the ID's sequence number
+// within its millisecond, as with the package-level NewWithSequence.
+func (g *Generator) NewWithSequence() (string, uint64, error) {
+	u, sequence, err := g.generate(0, true)
+	if err != nil {
+		return "", 0, err
+	}
+	return g.encode(&u), sequence, nil
+}
+
+// NewTimeWithSequence is like NewWithSequence but uses the given timestamp in
+// milliseconds.
+func (g *Generator) NewTimeWithSequence(timestamp uint64) (string, uint64, error) {
+	if timestamp > maxTimestamp {
+		return "", 0, ErrTimestampOverflow
+	}
+
+	u, sequence, err := g.generate(timestamp, false)
+	if err != nil {
+		return "", 0, err
+	}
+	return g.encode(&u), sequence, nil
+}
@@ -0,0 +1,37 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTimeWithSequence(t *testing.T) {
+	timestamp := uint64(time.Now().Add(48 * time.Hour).UnixMilli())
+
+	previous := ""
+	for want := range uint64(5) {
+		ulidStr, sequence, err := NewTimeWithSequence(timestamp)
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		if sequence != want {
+			t.Errorf("Sequence mismatch: got %d, expected %d", sequence, want)
+		}
+		if ulidStr <= previous {
+			t.Errorf("Monotonicity failed: %s <= %s", ulidStr, previous)
+		}
+		previous = ulidStr
+	}
+
+	_, sequence, err := NewTimeWithSequence(timestamp + 1)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if sequence != 0 {
+		t.Errorf("Expected sequence to restart in a new millisecond, got %d", sequence)
+	}
+
+	if _, _, err := NewTimeWithSequence(maxTimestamp + 1); err == nil {
+		t.Errorf("Expected error for timestamp overflow")
+	}
+}
@@ -0,0 +1,87 @@
+package ulid
+
+import (
+	"errors"
+	"runtime"
+	"sync/atomic"
+)
+
+// maxShards bounds the shard count so that shards reserve at most the top
+// 8 bits of the randomness
+const maxShards = 256
+
+// ShardedGenerator spreads generation over several independent monotonic
+// states, one per shard, so that many goroutines calling New do not contend
+// on a single lock. Each shard owns a disjoint slice of the randomness space,
+// distinguished by its top randomness bits, so shards never produce the same
+// ID.
+//
+// Sharding gives up strict global ordering: IDs sort by millisecond, and IDs
+// from one shard are monotonic, but IDs generated in the same millisecond by
+// different shards are in no particular order, even when they come from the
+// same goroutine. Use a Generator where strict monotonic ordering is required.
+//
+// A ShardedGenerator is safe for concurrent use.
+type ShardedGenerator struct {
+	shards []*EntropyPartition
+	next   atomic.Uint64
+}
+
+// NewShardedGenerator returns a ShardedGenerator with the given number of
+// shards, which must be a power of two between 1 and 256. A count of 0
+// selects the smallest power of two not below GOMAXPROCS.
+func NewShardedGenerator(shards int) (*ShardedGenerator, error) {
+	if shards == 0 {
+		shards = 1
+		for shards < runtime.GOMAXPROCS(0) && shards < maxShards {
+			shards <<= 1
+		}
+	}
+	if shards < 1 || shards > maxShards || shards&(shards-1) != 0 {
+		return nil, errors.New("shard count must be a power of two between 1 and 256")
+	}
+
+	partitions, err := PartitionEntropy(shards)
+	if err != nil {
+		return nil, err
+	}
+	return &ShardedGenerator{shards: partitions}, nil
+}
+
+// Shards returns the number of shards.
+func (s *ShardedGenerator) Shards() int {
+	return len(s.shards)
+}
+
+// New returns a new ULID string using the current time.
+func (s *ShardedGenerator) New() (string, error) {
+	u, err := s.NewULID()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// NewTime returns a new ULID string with the given timestamp in milliseconds.
+func (s *ShardedGenerator) NewTime(timestamp uint64) (string, error) {
+	u, err := s.NewULIDTime(timestamp)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// NewULID returns a new ULID struct using the current time.
+func (s *ShardedGenerator) NewULID() (ULID, error) {
+	return s.shard().newULIDTime(uint64(timeNow().UnixMilli()))
+}
+
+// NewULIDTime returns a new ULID struct with the given timestamp in milliseconds.
+func (s *ShardedGenerator) NewULIDTime(timestamp uint64) (ULID, error) {
+	return s.shard().newULIDTime(timestamp)
+}
+
+// shard picks the next shard in round-robin order
+func (s *ShardedGenerator) shard() *EntropyPartition {
+	return s.shards[(s.next.Add(1)-1)%uint64(len(s.shards))]
+}
@@ -0,0 +1,74 @@
+package ulid
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShardedGenerator(t *testing.T) {
+	s, err := NewShardedGenerator(4)
+	if err != nil {
+		t.Fatalf("Error creating sharded generator: %v", err)
+	}
+	timestamp := uint64(time.Now().UnixMilli())
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[ULID]bool)
+	shards := make(map[byte]bool)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 500 {
+				u, err := s.NewULIDTime(timestamp)
+				if err != nil {
+					t.Errorf("Error generating ULID: %v", err)
+					return
+				}
+				mu.Lock()
+				if seen[u] {
+					t.Errorf("Duplicate ULID: %s", u)
+				}
+				seen[u] = true
+				shards[u.randomness[0]>>6] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(shards) != 4 {
+		t.Errorf("Expected IDs from 4 shards, got %d", len(shards))
+	}
+}
+
+func TestNewShardedGeneratorCount(t *testing.T) {
+	for _, n := range []int{-1, 3, 512} {
+		if _, err := NewShardedGenerator(n); err == nil {
+			t.Errorf("Expected error for %d shards", n)
+		}
+	}
+
+	s, err := NewShardedGenerator(0)
+	if err != nil {
+		t.Fatalf("Error creating sharded generator: %v", err)
+	}
+	if s.Shards() < runtime.GOMAXPROCS(0) && s.Shards() != maxShards {
+		t.Errorf("Expected at least GOMAXPROCS shards, got %d", s.Shards())
+	}
+	if _, err := s.New(); err != nil {
+		t.Errorf("Error generating ULID: %v", err)
+	}
+}
+
+func BenchmarkShardedGeneratorParallel(b *testing.B) {
+	s, _ := NewShardedGenerator(0)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = s.NewULID()
+		}
+	})
+}
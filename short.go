@@ -0,0 +1,179 @@
+package ulid
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// maxShortRandomBytes is the largest randomness of a short ULID; a full ULID
+// has 10 bytes
+const maxShortRandomBytes = randomnessBytes - 1
+
+// ShortFormat describes a shortened ULID: the full 48-bit millisecond
+// timestamp followed by fewer random bytes, encoded in Crockford Base32 like
+// a ULID. Short IDs suit low-cardinality, human-facing contexts such as order
+// numbers, where a 26-character ID is unwieldy and the ID rate is modest.
+//
+// Fewer random bits make collisions among IDs of the same millisecond
+// likely at far lower rates than for ULIDs; CollisionProbability quantifies
+// the risk for a format. Short IDs are not monotonic within a millisecond.
+type ShortFormat struct {
+	randomBytes int
+}
+
+var (
+	// Short40 has 40 random bits and encodes to 18 characters. Two IDs in
+	// the same millisecond collide with probability about 1e-12; 1,000 IDs
+	// in one millisecond have about a 4.5e-7 chance of any collision.
+	Short40 = ShortFormat{randomBytes: 5}
+
+	// Short56 has 56 random bits and encodes to 21 characters. 100,000 IDs
+	// in one millisecond have about a 7e-8 chance of any collision.
+	Short56 = ShortFormat{randomBytes: 7}
+)
+
+// NewShortFormat returns the short format with the given number of random
+// bytes, between 1 and 9.
+func NewShortFormat(randomBytes int) (ShortFormat, error) {
+	if randomBytes < 1 || randomBytes > maxShortRandomBytes {
+		return ShortFormat{}, fmt.Errorf("short ULID random bytes must be between 1 and %d, got %d", maxShortRandomBytes, randomBytes)
+	}
+	return ShortFormat{randomBytes: randomBytes}, nil
+}
+
+// RandomBits returns the number of random bits of IDs in the format.
+func (f ShortFormat) RandomBits() int {
+	return f.randomBytes * 8
+}
+
+// Len returns the length of the string form of IDs in the format.
+func (f ShortFormat) Len() int {
+	return EncodedBase32Len(timestampBytes + f.randomBytes)
+}
+
+// CollisionProbability returns the probability that at least two of n IDs
+// generated in the same millisecond are equal, by the birthday bound.
+func (f ShortFormat) CollisionProbability(n int) float64 {
+	if n < 2 {
+		return 0
+	}
+	pairs := float64(n) * float64(n-1) / 2
+	return -math.Expm1(-pairs / math.Ldexp(1, f.RandomBits()))
+}
+
+// New returns a short ULID with the current time.
+func (f ShortFormat) New() (ShortULID, error) {
+	return f.NewTime(uint64(timeNow().UnixMilli()))
+}
+
+// NewTime returns a short ULID with the given timestamp in milliseconds.
+func (f ShortFormat) NewTime(timestamp uint64) (ShortULID, error) {
+	if err := f.check(); err != nil {
+		return ShortULID{}, err
+	}
+	if timestamp > maxTimestamp {
+		return ShortULID{}, ErrTimestampOverflow
+	}
+
+	s := ShortULID{timestamp: timestamp, randomBytes: uint8(f.randomBytes)}
+	if _, err := rand.Read(s.randomness[:f.randomBytes]); err != nil {
+		return ShortULID{}, err
+	}
+	return s, nil
+}
+
+// Parse parses a short ULID string of the format. Decoding is case
+// insensitive and accepts the Crockford aliases like Parse.
+func (f ShortFormat) Parse(s string) (ShortULID, error) {
+	if err := f.check(); err != nil {
+		return ShortULID{}, err
+	}
+	if len(s) != f.Len() {
+		return ShortULID{}, lengthError(f.Len(), len(s))
+	}
+	for i := range len(s) {
+		if decodeTable[s[i]] == 0xFF {
+			return ShortULID{}, &InvalidCharacterError{Pos: i, Char: s[i]}
+		}
+	}
+
+	var data [timestampBytes + maxShortRandomBytes]byte
+	if _, err := DecodeBase32(data[:], s); err != nil {
+		return ShortULID{}, err
+	}
+
+	u := ShortULID{randomBytes: uint8(f.randomBytes)}
+	for _, b := range data[:timestampBytes] {
+		u.timestamp = u.timestamp<<8 | uint64(b)
+	}
+	copy(u.randomness[:], data[timestampBytes:timestampBytes+f.randomBytes])
+	return u, nil
+}
+
+// IsValid reports whether s would be accepted by Parse, checking only the
+// length and alphabet.
+func (f ShortFormat) IsValid(s string) bool {
+	if f.randomBytes == 0 || len(s) != f.Len() {
+		return false
+	}
+	for i := range len(s) {
+		if decodeTable[s[i]] == 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// check rejects the zero ShortFormat
+func (f ShortFormat) check() error {
+	if f.randomBytes == 0 {
+		return errors.New("short ULID format has no random bytes; use NewShortFormat")
+	}
+	return nil
+}
+
+// ShortULID is an ID of a ShortFormat.
+type ShortULID struct {
+	timestamp   uint64
+	randomness  [maxShortRandomBytes]byte
+	randomBytes uint8
+}
+
+// Format returns the format of the ID.
+func (u ShortULID) Format() ShortFormat {
+	return ShortFormat{randomBytes: int(u.randomBytes)}
+}
+
+// Time returns the embedded timestamp as a UTC time.Time with millisecond
+// precision.
+func (u ShortULID) Time() time.Time {
+	return time.UnixMilli(int64(u.timestamp)).UTC()
+}
+
+// GetTime returns the embedded timestamp in Unix milliseconds.
+func (u ShortULID) GetTime() uint64 {
+	return u.timestamp
+}
+
+// String returns the Crockford Base32 form of the ID, in upper case if
+// enabled with SetUppercase.
+func (u ShortULID) String() string {
+	var data [timestampBytes + maxShortRandomBytes]byte
+	for i := range timestampBytes {
+		data[i] = byte(u.timestamp >> (8 * (timestampBytes - 1 - i)))
+	}
+	copy(data[timestampBytes:], u.randomness[:u.randomBytes])
+
+	n := timestampBytes + int(u.randomBytes)
+	buf := make([]byte, EncodedBase32Len(n))
+	EncodeBase32(buf, data[:n])
+	if uppercase.Load() {
+		for i, c := range buf {
+			buf[i] = lowerToUpper(c)
+		}
+	}
+	return string(buf)
+}
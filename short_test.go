@@ -0,0 +1,86 @@
+package ulid
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShortFormat(t *testing.T) {
+	timestamp := uint64(time.Now().UnixMilli())
+	for _, f := range []ShortFormat{Short40, Short56} {
+		u, err := f.NewTime(timestamp)
+		if err != nil {
+			t.Fatalf("Error generating short ULID: %v", err)
+		}
+		s := u.String()
+		if len(s) != f.Len() {
+			t.Errorf("Length mismatch: got %d, expected %d", len(s), f.Len())
+		}
+		if !f.IsValid(s) {
+			t.Errorf("Expected %q to be valid", s)
+		}
+
+		parsed, err := f.Parse(strings.ToUpper(s))
+		if err != nil {
+			t.Fatalf("Error parsing %q: %v", s, err)
+		}
+		if parsed != u {
+			t.Errorf("Round trip mismatch: got %v, expected %v", parsed, u)
+		}
+		if parsed.GetTime() != timestamp {
+			t.Errorf("Timestamp mismatch: got %d, expected %d", parsed.GetTime(), timestamp)
+		}
+	}
+
+	if Short40.Len() != 18 {
+		t.Errorf("Expected 18 characters for Short40, got %d", Short40.Len())
+	}
+}
+
+func TestShortFormatSortsByTime(t *testing.T) {
+	earlier, _ := Short40.NewTime(1000)
+	later, _ := Short40.NewTime(1001)
+	if earlier.String() >= later.String() {
+		t.Errorf("Expected %s to sort before %s", earlier, later)
+	}
+}
+
+func TestShortFormatParseErrors(t *testing.T) {
+	if _, err := Short40.Parse("0123"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Expected ErrInvalidLength, got %v", err)
+	}
+	if _, err := Short40.Parse("01arz3ndektsv4rrf!"); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("Expected ErrInvalidCharacter, got %v", err)
+	}
+	if _, err := (ShortFormat{}).New(); err == nil {
+		t.Error("Expected an error from the zero ShortFormat")
+	}
+}
+
+func TestNewShortFormat(t *testing.T) {
+	for _, n := range []int{0, 10} {
+		if _, err := NewShortFormat(n); err == nil {
+			t.Errorf("Expected an error for %d random bytes", n)
+		}
+	}
+	f, err := NewShortFormat(5)
+	if err != nil || f != Short40 {
+		t.Errorf("Expected Short40, got %v, %v", f, err)
+	}
+}
+
+func TestShortFormatCollisionProbability(t *testing.T) {
+	if p := Short40.CollisionProbability(1); p != 0 {
+		t.Errorf("Expected no collision risk for one ID, got %g", p)
+	}
+	want := 1 / math.Ldexp(1, 40)
+	if p := Short40.CollisionProbability(2); math.Abs(p-want) > want*1e-9 {
+		t.Errorf("Collision probability mismatch: got %g, expected %g", p, want)
+	}
+	if p := Short40.CollisionProbability(1000); p < 4e-7 || p > 5e-7 {
+		t.Errorf("Unexpected collision probability for 1,000 IDs: %g", p)
+	}
+}
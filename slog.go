@@ -0,0 +1,48 @@
+package ulid
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogAttributeKey is the attribute key used by LogHandler.
+const LogAttributeKey = "request_id"
+
+// LogHandler is a slog.Handler middleware that appends the ULID stored in the
+// record's context (see ContextWith) as a "request_id" attribute, so every log
+// call made with a request context is correlated without passing the ID
+// explicitly. Records logged without a context ULID are passed through
+// unchanged. Like any attribute added at Handle time, the ID is placed inside
+// the groups opened with WithGroup.
+type LogHandler struct {
+	next slog.Handler
+}
+
+// NewLogHandler wraps next with request ID injection.
+func NewLogHandler(next slog.Handler) *LogHandler {
+	return &LogHandler{next: next}
+}
+
+// Enabled implements slog.Handler.
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id, ok := FromContext(ctx); ok {
+		r = r.Clone()
+		r.AddAttrs(slog.String(LogAttributeKey, id.String()))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LogHandler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{next: h.next.WithGroup(name)}
+}
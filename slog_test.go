@@ -0,0 +1,31 @@
+package ulid
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewTextHandler(&buf, nil))).With("service", "api")
+
+	u := ULID{timestamp: 1678886400000}
+	logger.InfoContext(ContextWith(context.Background(), u), "handled")
+
+	line := buf.String()
+	if !strings.Contains(line, "request_id="+u.String()) {
+		t.Errorf("Expected request_id attribute, got: %s", line)
+	}
+	if !strings.Contains(line, "service=api") {
+		t.Errorf("Expected attributes from With to be kept, got: %s", line)
+	}
+
+	buf.Reset()
+	logger.InfoContext(context.Background(), "no id")
+	if strings.Contains(buf.String(), "request_id") {
+		t.Errorf("Unexpected request_id attribute without context ULID: %s", buf.String())
+	}
+}
@@ -0,0 +1,95 @@
+package ulid
+
+import "slices"
+
+// Sort sorts ids in ascending order.
+func Sort(ids []ULID) {
+	slices.SortFunc(ids, Compare)
+}
+
+// IsSorted reports whether ids is sorted in ascending order.
+func IsSorted(ids []ULID) bool {
+	return slices.IsSortedFunc(ids, Compare)
+}
+
+// SortStrings sorts ULID strings in ascending ULID order. Letter case is
+// ignored, so mixed-case input from different producers sorts correctly.
+// The strings are not validated; use Parse first if they may be malformed.
+func SortStrings(ids []string) {
+	slices.SortFunc(ids, compareFold)
+}
+
+// LessStrings reports whether the ULID string a sorts before b, ignoring
+// letter case.
+func LessStrings(a, b string) bool {
+	return compareFold(a, b) < 0
+}
+
+// StringsAreSorted reports whether the ULID strings are sorted in ascending
+// ULID order, ignoring letter case.
+func StringsAreSorted(ids []string) bool {
+	return slices.IsSortedFunc(ids, compareFold)
+}
+
+// CompareStrings validates two ULID strings and compares them by their
+// normalized symbol values, without decoding them to binary. Letter case and
+// the Crockford aliases are normalized, so the result matches comparing the
+// parsed ULIDs, except that strings differing only in the padding bits of
+// the last character compare unequal.
+func CompareStrings(a, b string) (int, error) {
+	if len(a) != encodedLength {
+		return 0, lengthError(encodedLength, len(a))
+	}
+	if len(b) != encodedLength {
+		return 0, lengthError(encodedLength, len(b))
+	}
+
+	result := 0
+	for i := range encodedLength {
+		va, vb := decodeTable[a[i]], decodeTable[b[i]]
+		if va == 0xFF {
+			return 0, &InvalidCharacterError{Pos: i, Char: a[i]}
+		}
+		if vb == 0xFF {
+			return 0, &InvalidCharacterError{Pos: i, Char: b[i]}
+		}
+		if result == 0 && va != vb {
+			if va < vb {
+				result = -1
+			} else {
+				result = 1
+			}
+		}
+	}
+	return result, nil
+}
+
+// compareFold compares two ULID strings with ASCII letters folded to lower case
+func compareFold(a, b string) int {
+	n := min(len(a), len(b))
+	for i := range n {
+		ca, cb := lowerASCII(a[i]), lowerASCII(b[i])
+		if ca != cb {
+			if ca < cb {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// lowerASCII folds an ASCII upper-case letter to lower case
+func lowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + ('a' - 'A')
+	}
+	return c
+}
@@ -0,0 +1,94 @@
+package ulid
+
+import (
+	"errors"
+	mathrand "math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	ids := []ULID{
+		{timestamp: 3},
+		{timestamp: 1, randomness: [randomnessBytes]byte{2}},
+		{timestamp: 1, randomness: [randomnessBytes]byte{1}},
+	}
+	if IsSorted(ids) {
+		t.Errorf("Expected unsorted input to be reported as unsorted")
+	}
+	Sort(ids)
+	if !IsSorted(ids) {
+		t.Errorf("Expected sorted output, got %v", ids)
+	}
+	if ids[0].randomness[0] != 1 || ids[2].timestamp != 3 {
+		t.Errorf("Unexpected sort order: %v", ids)
+	}
+}
+
+func TestSortStrings(t *testing.T) {
+	var want []string
+	for i := range 50 {
+		ulidStr, err := NewTime(1700000000000 + uint64(i))
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		want = append(want, ulidStr)
+	}
+
+	mixed := slices.Clone(want)
+	for i := range mixed {
+		if i%2 == 0 {
+			mixed[i] = strings.ToUpper(mixed[i])
+		}
+	}
+	slices.Reverse(mixed)
+	if StringsAreSorted(mixed) {
+		t.Errorf("Expected reversed input to be reported as unsorted")
+	}
+
+	SortStrings(mixed)
+	if !StringsAreSorted(mixed) {
+		t.Errorf("Expected sorted output")
+	}
+	for i := range mixed {
+		if strings.ToLower(mixed[i]) != want[i] {
+			t.Fatalf("Sort mismatch at %d: got %s, expected %s", i, mixed[i], want[i])
+		}
+	}
+
+	if !LessStrings("01ARZ3NDEKTSV4RRFFQ69G5FAR", "01arz3ndektsv4rrffq69g5fav") {
+		t.Errorf("Expected case-insensitive comparison")
+	}
+}
+
+func TestCompareStrings(t *testing.T) {
+	for range 200 {
+		a, err := NewULIDTime(1700000000000 + uint64(mathrand.IntN(3)))
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		b, err := NewULIDTime(1700000000000 + uint64(mathrand.IntN(3)))
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+
+		got, err := CompareStrings(a.String(), strings.ToUpper(b.String()))
+		if err != nil {
+			t.Fatalf("Error comparing strings: %v", err)
+		}
+		if want := a.Compare(b); got != want {
+			t.Errorf("CompareStrings(%s, %s) = %d, expected %d", a, b, got, want)
+		}
+	}
+
+	if c, err := CompareStrings("0iarz3ndektsv4rrffq69g5far", "01ARZ3NDEKTSV4RRFFQ69G5FAR"); err != nil || c != 0 {
+		t.Errorf("Expected aliases and case to normalize, got %d, %v", c, err)
+	}
+	if _, err := CompareStrings("01arz3ndektsv4rrffq69g5far", "01arz3ndektsv4rrffq69g5fa!"); !errors.Is(err, ErrInvalidCharacter) {
+		t.Errorf("Expected ErrInvalidCharacter, got %v", err)
+	}
+	if _, err := CompareStrings("short", "01arz3ndektsv4rrffq69g5far"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Expected ErrInvalidLength, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package ulid
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+// GeneratorStats is a snapshot of the counters maintained by the ULID generator.
+type GeneratorStats struct {
+	// Generated is the total number of ULIDs generated.
+	Generated uint64
+
+	// SameMillisecondSequences counts the milliseconds in which more than
+	// one ULID was generated.
+	SameMillisecondSequences uint64
+
+	// MaxBurst is the largest number of ULIDs generated within a single millisecond.
+	MaxBurst uint64
+
+	// MonotonicBumps counts how often the previous randomness was incremented
+	// to preserve ordering within a millisecond.
+	MonotonicBumps uint64
+
+	// Overflows counts randomness overflows within a millisecond, each
+	// resolved according to the generator's OverflowPolicy.
+	Overflows uint64
+
+	// EntropyRefills counts reads from the entropy source.
+	EntropyRefills uint64
+
+	// EntropyFailures counts failed reads from the entropy source.
+	EntropyFailures uint64
+
+	// FallbackReads counts reads served by the fallback entropy source set
+	// with WithFallbackEntropy.
+	FallbackReads uint64
+
+	// ClockRegressions counts how often the wall clock was observed moving
+	// backwards, as reported to OnClockBackwards.
+	ClockRegressions uint64
+
+	// ClockSkew is how far the timestamp of the latest ID generated from the
+	// clock was ahead of the wall clock, e.g. while ClockRegressionMonotonic
+	// bridges a wall-clock regression. A large value points at a clock that
+	// was stepped back and has not caught up yet.
+	ClockSkew time.Duration
+
+	// LockWait is the total time spent waiting for the lock that serializes
+	// same-millisecond generation.
+	LockWait time.Duration
+}
+
+// Stats returns a snapshot of the generation counters of the package-level
+// generator used by New and NewTime.
+func Stats() GeneratorStats {
+	return Default().Stats()
+}
+
+// Var returns an expvar.Var reporting the Stats of g as JSON, for publishing
+// with expvar.Publish under a name of the caller's choosing.
+func (g *Generator) Var() expvar.Var {
+	return expvar.Func(func() any {
+		return g.Stats()
+	})
+}
+
+// PublishExpvar publishes the Stats of the package-level generator, following
+// SetDefault, as an expvar variable with the given name, served at
+// /debug/vars by the expvar handler. Like expvar.Publish, it panics if the
+// name is already registered.
+func PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return Default().Stats()
+	}))
+}
+
+// Stats returns a snapshot of the generation counters of g. Counters are
+// read individually, so a snapshot taken during generation may be off by the
+// IDs in flight.
+func (g *Generator) Stats() GeneratorStats {
+	return GeneratorStats{
+		Generated:                g.stats.generated.Load(),
+		SameMillisecondSequences: g.stats.sameMillisecondSequences.Load(),
+		MaxBurst:                 g.stats.maxBurst.Load(),
+		MonotonicBumps:           g.stats.monotonicBumps.Load(),
+		Overflows:                g.stats.overflows.Load(),
+		EntropyRefills:           g.stats.entropyRefills.Load(),
+		EntropyFailures:          g.stats.entropyFailures.Load(),
+		FallbackReads:            g.stats.fallbackReads.Load(),
+		ClockRegressions:         g.stats.clockRegressions.Load(),
+		ClockSkew:                time.Duration(g.stats.clockSkew.Load()),
+		LockWait:                 time.Duration(g.stats.lockWait.Load()),
+	}
+}
+
+// generatorEvents flags what happened while generating an ID
+type generatorEvents uint8
+
+const (
+	eventMonotonicBump generatorEvents = 1 << iota
+	eventOverflow
+	eventEntropyRefill
+	eventEntropyFailure
+)
+
+// generatorCounters holds the counters behind GeneratorStats
+type generatorCounters struct {
+	generated                atomic.Uint64
+	sameMillisecondSequences atomic.Uint64
+	maxBurst                 atomic.Uint64
+	monotonicBumps           atomic.Uint64
+	overflows                atomic.Uint64
+	entropyRefills           atomic.Uint64
+	entropyFailures          atomic.Uint64
+	fallbackReads            atomic.Uint64
+	clockRegressions         atomic.Uint64
+	clockSkew                atomic.Int64  // nanoseconds
+	lockWait                 atomic.Uint64 // nanoseconds
+}
+
+// record counts a committed ID that was the burst-th in its millisecond
+func (c *generatorCounters) record(burst uint64, events generatorEvents) {
+	c.generated.Add(1)
+	if burst == 2 {
+		c.sameMillisecondSequences.Add(1)
+	}
+	for {
+		current := c.maxBurst.Load()
+		if burst <= current || c.maxBurst.CompareAndSwap(current, burst) {
+			break
+		}
+	}
+	if events&eventMonotonicBump != 0 {
+		c.monotonicBumps.Add(1)
+	}
+	if events&eventOverflow != 0 {
+		c.overflows.Add(1)
+	}
+	if events&eventEntropyRefill != 0 {
+		c.entropyRefills.Add(1)
+	}
+}
@@ -0,0 +1,56 @@
+package ulid
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	before := Stats()
+
+	// A timestamp no other test uses so the burst starts fresh
+	timestamp := uint64(time.Now().Add(24 * time.Hour).UnixMilli())
+	for range 3 {
+		if _, err := NewTime(timestamp); err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+	}
+
+	after := Stats()
+
+	if got := after.Generated - before.Generated; got != 3 {
+		t.Errorf("Generated mismatch: got %d, expected 3", got)
+	}
+	if got := after.SameMillisecondSequences - before.SameMillisecondSequences; got != 1 {
+		t.Errorf("SameMillisecondSequences mismatch: got %d, expected 1", got)
+	}
+	if after.MaxBurst < 3 {
+		t.Errorf("MaxBurst mismatch: got %d, expected at least 3", after.MaxBurst)
+	}
+	if got := after.EntropyRefills - before.EntropyRefills; got != 3 {
+		t.Errorf("EntropyRefills mismatch: got %d, expected 3", got)
+	}
+	if after.MonotonicBumps < before.MonotonicBumps {
+		t.Errorf("MonotonicBumps decreased: got %d, before %d", after.MonotonicBumps, before.MonotonicBumps)
+	}
+}
+
+func TestGeneratorVar(t *testing.T) {
+	g := NewGenerator(WithEntropy(bytes.NewReader(make([]byte, randomnessBytes))))
+	if _, err := g.New(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if _, err := g.New(); err == nil {
+		t.Fatal("Expected an error from the exhausted entropy source")
+	}
+
+	var stats GeneratorStats
+	if err := json.Unmarshal([]byte(g.Var().String()), &stats); err != nil {
+		t.Fatalf("Error decoding expvar JSON: %v", err)
+	}
+	if stats.Generated != 1 || stats.EntropyFailures != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,109 @@
+package ulid
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"iter"
+)
+
+// Records returns an iterator over a stream of concatenated 16-byte binary
+// ULIDs, as dumped from BINARY(16) columns. Iteration stops at the end of the
+// stream; a read error or a truncated final record is yielded once as the
+// error of the last pair.
+func Records(r io.Reader) iter.Seq2[ULID, error] {
+	return func(yield func(ULID, error) bool) {
+		// Buffer unbuffered readers to avoid a read call per record
+		if _, ok := r.(io.ByteReader); !ok {
+			r = bufio.NewReader(r)
+		}
+
+		var data [totalBytes]byte
+		for {
+			_, err := io.ReadFull(r, data[:])
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				if err == io.ErrUnexpectedEOF {
+					err = errors.New("truncated ULID record")
+				}
+				yield(ULID{}, err)
+				return
+			}
+			if !yield(fromBytes(data), nil) {
+				return
+			}
+		}
+	}
+}
+
+// ReadAll reads a stream of concatenated 16-byte binary ULIDs until EOF.
+func ReadAll(r io.Reader) ([]ULID, error) {
+	var ids []ULID
+	for id, err := range Records(r) {
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Stream starts a background goroutine that pre-generates IDs from g into a
+// channel with the given buffer size, so latency-critical paths can receive a
+// ready ID instead of generating inline. The goroutine stops and closes the
+// channel when ctx is done, even while waiting on a rate limit or for the
+// next millisecond, or if generation fails, in which case callers should fall
+// back to generating directly.
+//
+// IDs are generated ahead of time, so their timestamps may lag the moment
+// they are received by up to the time it takes to drain the buffer. IDs
+// received from one stream are in ascending order.
+func (g *Generator) Stream(ctx context.Context, buffer int) <-chan string {
+	ch := make(chan string, max(buffer, 0))
+	go func() {
+		defer close(ch)
+		for {
+			u, _, err := g.generateContext(ctx, 0, true, false)
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- g.encode(&u):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// All returns an iterator over IDs freshly generated by g, each using the
+// current time. Iteration continues until the loop exits, ctx is done or
+// generation fails; cancelling ctx also interrupts a wait on a rate limit or
+// for the next millisecond.
+func (g *Generator) All(ctx context.Context) iter.Seq[ULID] {
+	return g.take(ctx, -1)
+}
+
+// Take returns an iterator over n IDs freshly generated by g, as with All.
+// Iteration stops early if generation fails. Take waits like NewULID; use
+// All with a context to bound the waits.
+func (g *Generator) Take(n int) iter.Seq[ULID] {
+	return g.take(context.Background(), max(n, 0))
+}
+
+// take implements All and Take, yielding n IDs or, if n is negative, IDs
+// until ctx is done
+func (g *Generator) take(ctx context.Context, n int) iter.Seq[ULID] {
+	return func(yield func(ULID) bool) {
+		for i := 0; n < 0 || i < n; i++ {
+			u, _, err := g.generateContext(ctx, 0, true, false)
+			if err != nil || !yield(u) {
+				return
+			}
+		}
+	}
+}
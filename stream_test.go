@@ -0,0 +1,174 @@
+package ulid
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReadAll(t *testing.T) {
+	ids, err := GenerateBetween(time.Now().Add(-time.Hour), time.Now(), 50)
+	if err != nil {
+		t.Fatalf("Error generating ULIDs: %v", err)
+	}
+	buf := AppendFixedSizeBinary(nil, ids)
+
+	got, err := ReadAll(bytes.NewReader(buf))
+	if err != nil {
+		t.Fatalf("Error reading ULIDs: %v", err)
+	}
+	if len(got) != len(ids) {
+		t.Fatalf("Count mismatch: got %d, expected %d", len(got), len(ids))
+	}
+	for i := range ids {
+		if got[i] != ids[i] {
+			t.Fatalf("Record %d mismatch: got %v, expected %v", i, got[i], ids[i])
+		}
+	}
+
+	got, err = ReadAll(bytes.NewReader(buf[:len(buf)-3]))
+	if err == nil {
+		t.Errorf("Expected error for truncated record")
+	}
+	if len(got) != len(ids)-1 {
+		t.Errorf("Expected complete records before the truncation, got %d", len(got))
+	}
+}
+
+func TestRecordsEarlyStop(t *testing.T) {
+	buf := AppendFixedSizeBinary(nil, []ULID{{timestamp: 1}, {timestamp: 2}, {timestamp: 3}})
+
+	count := 0
+	for id, err := range Records(bytes.NewBuffer(buf)) {
+		if err != nil {
+			t.Fatalf("Error reading ULIDs: %v", err)
+		}
+		count++
+		if id.GetTime() == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("Expected iteration to stop after 2 records, got %d", count)
+	}
+}
+
+func TestGeneratorStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := NewGenerator().Stream(ctx, 16)
+
+	previous := ""
+	for range 100 {
+		id := <-ch
+		if !IsValid(id) {
+			t.Fatalf("Invalid ULID from stream: %q", id)
+		}
+		if id <= previous {
+			t.Fatalf("Stream not ascending: %s <= %s", id, previous)
+		}
+		previous = id
+	}
+
+	cancel()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Expected the stream to close after cancellation")
+		}
+	}
+}
+
+func TestGeneratorStreamCancelWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := NewGenerator(WithRateLimit(1, time.Hour, RateLimitWait)).Stream(ctx, 0)
+
+	if id := <-ch; !IsValid(id) {
+		t.Fatalf("Invalid ULID from stream: %q", id)
+	}
+
+	// The producer is now blocked on the rate limiter
+	time.AfterFunc(10*time.Millisecond, cancel)
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("Expected no further IDs within the rate limit")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the stream to close after cancellation")
+	}
+}
+
+func TestGeneratorTake(t *testing.T) {
+	g := NewGenerator()
+
+	var ids []ULID
+	for u := range g.Take(1000) {
+		ids = append(ids, u)
+	}
+	if len(ids) != 1000 {
+		t.Fatalf("Count mismatch: got %d, expected 1000", len(ids))
+	}
+	if !IsSorted(ids) {
+		t.Error("Expected IDs from Take to be sorted")
+	}
+
+	count := 0
+	for range g.Take(10) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("Expected early exit after 3 IDs, got %d", count)
+	}
+}
+
+func TestGeneratorAll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count := 0
+	for range NewGenerator().All(ctx) {
+		count++
+		if count == 50 {
+			cancel()
+		}
+	}
+	if count != 50 {
+		t.Errorf("Expected iteration to stop after cancellation at 50, got %d", count)
+	}
+}
+
+func TestGeneratorAllCancelWhileWaiting(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	g := NewGenerator(WithRateLimit(1, time.Hour, RateLimitWait))
+
+	done := make(chan int)
+	go func() {
+		count := 0
+		for range g.All(ctx) {
+			count++
+		}
+		done <- count
+	}()
+
+	// The second ID blocks on the rate limiter until ctx is cancelled
+	time.AfterFunc(10*time.Millisecond, cancel)
+	select {
+	case count := <-done:
+		if count != 1 {
+			t.Errorf("Expected 1 ID within the rate limit, got %d", count)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected iteration to stop after cancellation")
+	}
+}
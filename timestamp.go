@@ -0,0 +1,71 @@
+package ulid
+
+import "time"
+
+// Time returns the embedded timestamp as a UTC time.Time with millisecond
+// precision. GetTime returns the same instant as raw Unix milliseconds.
+func (u ULID) Time() time.Time {
+	return time.UnixMilli(int64(u.timestamp)).UTC()
+}
+
+// Sub returns the duration between the embedded timestamps of u and other
+// (u - other), which is negative when u is older than other.
+func (u ULID) Sub(other ULID) time.Duration {
+	return time.Duration(int64(u.timestamp)-int64(other.timestamp)) * time.Millisecond
+}
+
+// Add returns a copy of u whose timestamp is shifted by d, truncated to whole
+// milliseconds, keeping the randomness intact. It returns an error if the
+// result falls outside the 48-bit timestamp range.
+func (u ULID) Add(d time.Duration) (ULID, error) {
+	timestamp := int64(u.timestamp) + int64(d/time.Millisecond)
+	if timestamp < 0 || timestamp > maxTimestamp {
+		return ULID{}, ErrTimestampOverflow
+	}
+	u.timestamp = uint64(timestamp)
+	return u, nil
+}
+
+// Age returns the time elapsed since the ULID's embedded timestamp.
+func (u ULID) Age() time.Duration {
+	return time.Since(u.Time())
+}
+
+// Before reports whether u's timestamp is earlier than other's. The
+// randomness is ignored, so two ULIDs from the same millisecond are neither
+// before nor after each other.
+func (u ULID) Before(other ULID) bool {
+	return u.timestamp < other.timestamp
+}
+
+// After reports whether u's timestamp is later than other's, ignoring the
+// randomness.
+func (u ULID) After(other ULID) bool {
+	return u.timestamp > other.timestamp
+}
+
+// Between reports whether u's timestamp lies within [start, end], both
+// bounds inclusive at millisecond precision.
+func (u ULID) Between(start, end time.Time) bool {
+	t := int64(u.timestamp)
+	return t >= start.UnixMilli() && t <= end.UnixMilli()
+}
+
+// TruncateTime returns the ULID at the start of the d-sized time bucket
+// containing u: the timestamp rounded down to a multiple of d since the Unix
+// epoch, with zeroed randomness. Durations below one millisecond only clear
+// the randomness. Buckets are aligned to UTC, so day buckets start at midnight
+// UTC.
+func (u ULID) TruncateTime(d time.Duration) ULID {
+	ms := uint64(d / time.Millisecond)
+	if ms == 0 {
+		ms = 1
+	}
+	return ULID{timestamp: u.timestamp - u.timestamp%ms}
+}
+
+// BucketKey returns the string form of u.TruncateTime(d), a stable key for
+// mapping ULIDs to time-partitioned tables or object-store prefixes.
+func BucketKey(u ULID, d time.Duration) string {
+	return u.TruncateTime(d).String()
+}
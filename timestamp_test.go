@@ -0,0 +1,125 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	u := ULID{timestamp: 1678886400123}
+
+	got := u.Time()
+	if !got.Equal(time.UnixMilli(1678886400123)) {
+		t.Errorf("Time mismatch: got %v", got)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("Expected UTC location, got %v", got.Location())
+	}
+	if uint64(got.UnixMilli()) != u.GetTime() {
+		t.Errorf("Time and GetTime disagree: %d != %d", got.UnixMilli(), u.GetTime())
+	}
+}
+
+func TestSub(t *testing.T) {
+	a := ULID{timestamp: 1678886400000}
+	b := ULID{timestamp: 1678886401500}
+
+	if got := b.Sub(a); got != 1500*time.Millisecond {
+		t.Errorf("Sub mismatch: got %v, expected 1.5s", got)
+	}
+	if got := a.Sub(b); got != -1500*time.Millisecond {
+		t.Errorf("Sub mismatch: got %v, expected -1.5s", got)
+	}
+	if got := a.Sub(a); got != 0 {
+		t.Errorf("Sub mismatch: got %v, expected 0", got)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	u := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{1, 2, 3}}
+
+	later, err := u.Add(90 * time.Minute)
+	if err != nil {
+		t.Fatalf("Error adding duration: %v", err)
+	}
+	if later.Sub(u) != 90*time.Minute {
+		t.Errorf("Add mismatch: got %v", later.Sub(u))
+	}
+	if later.Entropy() != u.Entropy() {
+		t.Errorf("Expected randomness to be preserved")
+	}
+
+	earlier, err := u.Add(-1500 * time.Microsecond)
+	if err != nil {
+		t.Fatalf("Error adding duration: %v", err)
+	}
+	if earlier.GetTime() != u.GetTime()-1 {
+		t.Errorf("Expected truncation to whole milliseconds, got %d", earlier.GetTime())
+	}
+
+	if _, err := u.Add(-time.Duration(u.timestamp+1) * time.Millisecond); err == nil {
+		t.Errorf("Expected error for negative timestamp")
+	}
+	if _, err := Max.Add(time.Millisecond); err == nil {
+		t.Errorf("Expected error for timestamp overflow")
+	}
+}
+
+func TestTemporalComparisons(t *testing.T) {
+	a := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{9}}
+	b := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{1}}
+	c := ULID{timestamp: 1678886400001}
+
+	if a.Before(b) || a.After(b) {
+		t.Errorf("Expected same-millisecond ULIDs to be neither before nor after")
+	}
+	if !a.Before(c) || !c.After(a) {
+		t.Errorf("Expected timestamp ordering")
+	}
+
+	start := time.UnixMilli(1678886400000)
+	if !a.Between(start, start) {
+		t.Errorf("Expected inclusive bounds")
+	}
+	if c.Between(start.Add(-time.Hour), start) {
+		t.Errorf("Expected ULID after the window to be outside it")
+	}
+
+	u, err := NewULIDTime(uint64(time.Now().Add(-time.Hour).UnixMilli()))
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if age := u.Age(); age < time.Hour || age > time.Hour+time.Minute {
+		t.Errorf("Age mismatch: got %v", age)
+	}
+}
+
+func TestTruncateTime(t *testing.T) {
+	ts := time.Date(2024, 3, 15, 13, 47, 12, 345e6, time.UTC)
+	u := ULID{timestamp: uint64(ts.UnixMilli()), randomness: [randomnessBytes]byte{1, 2, 3}}
+
+	hour := u.TruncateTime(time.Hour)
+	if !hour.Time().Equal(time.Date(2024, 3, 15, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("Hour bucket mismatch: got %v", hour.Time())
+	}
+	if hour.Entropy() != Zero.Entropy() {
+		t.Errorf("Expected zeroed randomness")
+	}
+
+	day := u.TruncateTime(24 * time.Hour)
+	if !day.Time().Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Day bucket mismatch: got %v", day.Time())
+	}
+
+	if got := u.TruncateTime(0); got.GetTime() != u.GetTime() || got.Entropy() != Zero.Entropy() {
+		t.Errorf("Expected sub-millisecond truncation to keep the timestamp, got %s", got.DebugString())
+	}
+
+	other := ULID{timestamp: uint64(ts.Add(10 * time.Minute).UnixMilli()), randomness: [randomnessBytes]byte{9}}
+	if BucketKey(u, time.Hour) != BucketKey(other, time.Hour) {
+		t.Errorf("Expected ULIDs in the same hour to share a bucket key")
+	}
+	if BucketKey(u, time.Hour) != hour.String() {
+		t.Errorf("BucketKey mismatch: got %s, expected %s", BucketKey(u, time.Hour), hour)
+	}
+}
@@ -0,0 +1,33 @@
+package ulid
+
+import (
+	"context"
+	"errors"
+)
+
+// errWouldBlock reports that non-blocking generation would have to wait
+var errWouldBlock = errors.New("ULID generation would block")
+
+// TryNew is like New but never blocks: it returns ok == false instead of
+// waiting when another goroutine holds the lock for the current millisecond,
+// when the OverflowWaitForNextMillisecond policy would wait for the clock, or
+// when a RateLimitWait rate limit has no token available. Soft real-time
+// callers can then fall back to a locally cached ID. Errors are returned as
+// with New, including *RateLimitError in RateLimitReject mode.
+func TryNew() (string, bool, error) {
+	return Default().TryNew()
+}
+
+// TryNew is like New but never blocks, as with the package-level TryNew. A
+// state store set with WithStateStore is still read and written
+// synchronously.
+func (g *Generator) TryNew() (string, bool, error) {
+	u, _, err := g.generateContext(context.Background(), 0, true, true)
+	if errors.Is(err, errWouldBlock) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return g.encode(&u), true, nil
+}
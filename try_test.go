@@ -0,0 +1,62 @@
+package ulid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTryNew(t *testing.T) {
+	id, ok, err := TryNew()
+	if err != nil || !ok {
+		t.Fatalf("Expected an ID, got %q, %v, %v", id, ok, err)
+	}
+	if !IsValid(id) {
+		t.Errorf("Invalid ULID: %q", id)
+	}
+}
+
+func TestTryNewContended(t *testing.T) {
+	now := time.Now()
+	g := NewGenerator(WithClock(func() time.Time { return now }))
+	if _, err := g.New(); err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+
+	// Another goroutine is generating in the same millisecond
+	g.mu.Lock()
+	if _, ok, err := g.TryNew(); ok || err != nil {
+		t.Errorf("Expected TryNew to give up on a held lock, got %v, %v", ok, err)
+	}
+	g.mu.Unlock()
+
+	if _, ok, err := g.TryNew(); !ok || err != nil {
+		t.Errorf("Expected an ID once the lock is free, got %v, %v", ok, err)
+	}
+}
+
+func TestTryNewOverflowWait(t *testing.T) {
+	now := time.Now()
+	g := exhaustedGenerator(uint64(now.UnixMilli()),
+		WithClock(func() time.Time { return now }), WithOverflowPolicy(OverflowWaitForNextMillisecond))
+
+	if _, ok, err := g.TryNew(); ok || err != nil {
+		t.Errorf("Expected TryNew not to wait for the next millisecond, got %v, %v", ok, err)
+	}
+}
+
+func TestTryNewRateLimit(t *testing.T) {
+	g := NewGenerator(WithRateLimit(1, time.Hour, RateLimitWait))
+	if _, ok, err := g.TryNew(); !ok || err != nil {
+		t.Fatalf("Expected an ID, got %v, %v", ok, err)
+	}
+	if _, ok, err := g.TryNew(); ok || err != nil {
+		t.Errorf("Expected TryNew not to wait for a token, got %v, %v", ok, err)
+	}
+
+	g = NewGenerator(WithRateLimit(1, time.Hour, RateLimitReject))
+	_, _, _ = g.TryNew()
+	if _, ok, err := g.TryNew(); ok || !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited in reject mode, got %v, %v", ok, err)
+	}
+}
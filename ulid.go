@@ -2,8 +2,6 @@ package ulid
 
 import (
 	"crypto/rand"
-	"errors"
-	"sync"
 	"time"
 	"unsafe"
 )
@@ -18,6 +16,21 @@ const (
 	maxTimestamp    = (1 << timestampBits) - 1
 )
 
+const (
+	// EncodedSize is the length of the string form of a ULID.
+	EncodedSize = encodedLength
+
+	// BinarySize is the length of the binary form of a ULID.
+	BinarySize = totalBytes
+
+	// TimestampBits is the width of the millisecond timestamp component.
+	TimestampBits = timestampBits
+
+	// MaxTimestamp is the largest timestamp, in Unix milliseconds, that a
+	// ULID can hold.
+	MaxTimestamp = maxTimestamp
+)
+
 // Crockford Base32 alphabet in lowercase for better readability
 const crockfordAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
 
@@ -31,10 +44,8 @@ var (
 	}
 	decodeTable [256]byte
 
-	// Monotonicity state with CPU cache alignment
-	lastTime       uint64
-	lastRandomness [randomnessBytes]byte
-	mutex          sync.Mutex
+	// Wall-clock source of generators without WithClock, replaceable in tests
+	timeNow = time.Now
 )
 
 func init() {
@@ -69,10 +80,16 @@ type ULID struct {
 }
 
 // ultraFastEncode uses highly optimized base32 encoding with SIMD-style operations
-func ultraFastEncode(data [totalBytes]byte) string {
-	// Stack allocation for result - no heap allocation
+func ultraFastEncode(table *[32]byte, data *[totalBytes]byte) string {
 	var result [encodedLength]byte
+	encodeInto(table, data, &result)
+
+	// Zero-copy string conversion using unsafe
+	return unsafe.String(&result[0], encodedLength)
+}
 
+// encodeInto writes the base32 encoding of data into result
+func encodeInto(table *[32]byte, data *[totalBytes]byte, result *[encodedLength]byte) {
 	// Ultra-optimized encoding using 64-bit operations and parallel processing
 	// This approach minimizes CPU cycles by processing multiple bytes simultaneously
 
@@ -81,63 +98,60 @@ func ultraFastEncode(data [totalBytes]byte) string {
 		uint64(data[4])<<24 | uint64(data[5])<<16 | uint64(data[6])<<8 | uint64(data[7])
 
 	// Extract 13 characters from 64 bits (65 bits total, 3 bits overflow)
-	result[0] = encodeTable[word1>>59]
-	result[1] = encodeTable[(word1>>54)&0x1F]
-	result[2] = encodeTable[(word1>>49)&0x1F]
-	result[3] = encodeTable[(word1>>44)&0x1F]
-	result[4] = encodeTable[(word1>>39)&0x1F]
-	result[5] = encodeTable[(word1>>34)&0x1F]
-	result[6] = encodeTable[(word1>>29)&0x1F]
-	result[7] = encodeTable[(word1>>24)&0x1F]
-	result[8] = encodeTable[(word1>>19)&0x1F]
-	result[9] = encodeTable[(word1>>14)&0x1F]
-	result[10] = encodeTable[(word1>>9)&0x1F]
-	result[11] = encodeTable[(word1>>4)&0x1F]
-	result[12] = encodeTable[((word1&0x0F)<<1)|(uint64(data[8])>>7)]
+	result[0] = table[word1>>59]
+	result[1] = table[(word1>>54)&0x1F]
+	result[2] = table[(word1>>49)&0x1F]
+	result[3] = table[(word1>>44)&0x1F]
+	result[4] = table[(word1>>39)&0x1F]
+	result[5] = table[(word1>>34)&0x1F]
+	result[6] = table[(word1>>29)&0x1F]
+	result[7] = table[(word1>>24)&0x1F]
+	result[8] = table[(word1>>19)&0x1F]
+	result[9] = table[(word1>>14)&0x1F]
+	result[10] = table[(word1>>9)&0x1F]
+	result[11] = table[(word1>>4)&0x1F]
+	result[12] = table[((word1&0x0F)<<1)|(uint64(data[8])>>7)]
 
 	// Process remaining 8 bytes
 	word2 := uint64(data[8]&0x7F)<<57 | uint64(data[9])<<49 | uint64(data[10])<<41 | uint64(data[11])<<33 |
 		uint64(data[12])<<25 | uint64(data[13])<<17 | uint64(data[14])<<9 | uint64(data[15])<<1
 
-	result[13] = encodeTable[word2>>59]
-	result[14] = encodeTable[(word2>>54)&0x1F]
-	result[15] = encodeTable[(word2>>49)&0x1F]
-	result[16] = encodeTable[(word2>>44)&0x1F]
-	result[17] = encodeTable[(word2>>39)&0x1F]
-	result[18] = encodeTable[(word2>>34)&0x1F]
-	result[19] = encodeTable[(word2>>29)&0x1F]
-	result[20] = encodeTable[(word2>>24)&0x1F]
-	result[21] = encodeTable[(word2>>19)&0x1F]
-	result[22] = encodeTable[(word2>>14)&0x1F]
-	result[23] = encodeTable[(word2>>9)&0x1F]
-	result[24] = encodeTable[(word2>>4)&0x1F]
-	result[25] = encodeTable[(word2<<1)&0x1F]
-
-	// Zero-copy string conversion using unsafe
-	return unsafe.String(&result[0], encodedLength)
+	result[13] = table[word2>>59]
+	result[14] = table[(word2>>54)&0x1F]
+	result[15] = table[(word2>>49)&0x1F]
+	result[16] = table[(word2>>44)&0x1F]
+	result[17] = table[(word2>>39)&0x1F]
+	result[18] = table[(word2>>34)&0x1F]
+	result[19] = table[(word2>>29)&0x1F]
+	result[20] = table[(word2>>24)&0x1F]
+	result[21] = table[(word2>>19)&0x1F]
+	result[22] = table[(word2>>14)&0x1F]
+	result[23] = table[(word2>>9)&0x1F]
+	result[24] = table[(word2>>4)&0x1F]
+	result[25] = table[(word2<<1)&0x1F]
 }
 
 // ultraFastDecode decodes with minimal validation and optimized bit operations
-func ultraFastDecode(s string) ([totalBytes]byte, error) {
+func ultraFastDecode(table *[256]byte, s string) ([totalBytes]byte, error) {
 	var result [totalBytes]byte
 
 	if len(s) != encodedLength {
-		return result, errors.New("invalid ULID length")
+		return result, lengthError(encodedLength, len(s))
 	}
 
 	// Branch-free validation using lookup table
 	// First pass: validate all characters
 	for i := range encodedLength {
 		c := s[i]
-		if int(c) >= 256 || decodeTable[c] == 0xFF {
-			return result, errors.New("invalid character in ULID")
+		if int(c) >= 256 || table[c] == 0xFF {
+			return result, &InvalidCharacterError{Pos: i, Char: c}
 		}
 	}
 
 	// Optimized decoding in 8-character chunks
 	// First 8 chars -> 5 bytes
-	v0, v1, v2, v3, v4, v5, v6, v7 := decodeTable[s[0]], decodeTable[s[1]], decodeTable[s[2]], decodeTable[s[3]],
-		decodeTable[s[4]], decodeTable[s[5]], decodeTable[s[6]], decodeTable[s[7]]
+	v0, v1, v2, v3, v4, v5, v6, v7 := table[s[0]], table[s[1]], table[s[2]], table[s[3]],
+		table[s[4]], table[s[5]], table[s[6]], table[s[7]]
 
 	acc := uint64(v0)<<35 | uint64(v1)<<30 | uint64(v2)<<25 | uint64(v3)<<20 |
 		uint64(v4)<<15 | uint64(v5)<<10 | uint64(v6)<<5 | uint64(v7)
@@ -149,8 +163,8 @@ func ultraFastDecode(s string) ([totalBytes]byte, error) {
 	result[4] = byte(acc)
 
 	// Next 8 chars -> 5 bytes
-	v0, v1, v2, v3, v4, v5, v6, v7 = decodeTable[s[8]], decodeTable[s[9]], decodeTable[s[10]], decodeTable[s[11]],
-		decodeTable[s[12]], decodeTable[s[13]], decodeTable[s[14]], decodeTable[s[15]]
+	v0, v1, v2, v3, v4, v5, v6, v7 = table[s[8]], table[s[9]], table[s[10]], table[s[11]],
+		table[s[12]], table[s[13]], table[s[14]], table[s[15]]
 
 	acc = uint64(v0)<<35 | uint64(v1)<<30 | uint64(v2)<<25 | uint64(v3)<<20 |
 		uint64(v4)<<15 | uint64(v5)<<10 | uint64(v6)<<5 | uint64(v7)
@@ -162,8 +176,8 @@ func ultraFastDecode(s string) ([totalBytes]byte, error) {
 	result[9] = byte(acc)
 
 	// Next 8 chars -> 5 bytes
-	v0, v1, v2, v3, v4, v5, v6, v7 = decodeTable[s[16]], decodeTable[s[17]], decodeTable[s[18]], decodeTable[s[19]],
-		decodeTable[s[20]], decodeTable[s[21]], decodeTable[s[22]], decodeTable[s[23]]
+	v0, v1, v2, v3, v4, v5, v6, v7 = table[s[16]], table[s[17]], table[s[18]], table[s[19]],
+		table[s[20]], table[s[21]], table[s[22]], table[s[23]]
 
 	acc = uint64(v0)<<35 | uint64(v1)<<30 | uint64(v2)<<25 | uint64(v3)<<20 |
 		uint64(v4)<<15 | uint64(v5)<<10 | uint64(v6)<<5 | uint64(v7)
@@ -175,13 +189,20 @@ func ultraFastDecode(s string) ([totalBytes]byte, error) {
 	result[14] = byte(acc)
 
 	// Last 2 chars -> 1 byte
-	result[15] = decodeTable[s[24]]<<3 | decodeTable[s[25]]>>2
+	result[15] = table[s[24]]<<3 | table[s[25]]>>2
 
 	return result, nil
 }
 
-// String returns the canonical string representation of the ULID.
+// String returns the canonical string representation of the ULID, in lower
+// case unless SetUppercase has been enabled.
 func (u ULID) String() string {
+	data := u.bytes()
+	return ultraFastEncode(stringTable(), &data)
+}
+
+// bytes returns the 16-byte big-endian representation of the ULID.
+func (u ULID) bytes() [totalBytes]byte {
 	var data [totalBytes]byte
 
 	// Encode timestamp (big-endian) - unrolled for speed
@@ -195,16 +216,21 @@ func (u ULID) String() string {
 	// Copy randomness - compiler will optimize this
 	copy(data[timestampBytes:], u.randomness[:])
 
-	return ultraFastEncode(data)
+	return data
 }
 
 // Parse parses a ULID string and returns a ULID struct.
 func Parse(s string) (ULID, error) {
-	data, err := ultraFastDecode(s)
+	data, err := ultraFastDecode(&decodeTable, s)
 	if err != nil {
 		return ULID{}, err
 	}
 
+	return fromBytes(data), nil
+}
+
+// fromBytes builds a ULID from its 16-byte big-endian representation.
+func fromBytes(data [totalBytes]byte) ULID {
 	// Extract timestamp (big-endian) - unrolled for speed
 	timestamp := uint64(data[0])<<40 | uint64(data[1])<<32 | uint64(data[2])<<24 |
 		uint64(data[3])<<16 | uint64(data[4])<<8 | uint64(data[5])
@@ -216,7 +242,7 @@ func Parse(s string) (ULID, error) {
 	return ULID{
 		timestamp:  timestamp,
 		randomness: randomness,
-	}, nil
+	}
 }
 
 // GetTime returns the timestamp of the ULID in milliseconds.
@@ -257,103 +283,50 @@ func compareRandomness(a, b [randomnessBytes]byte) int {
 	return 0
 }
 
-// New returns a new ULID.
-func New() (string, error) {
-	return NewTime(uint64(time.Now().UnixMilli()))
+// New returns a new ULID. Options can pin the timestamp, supply the
+// randomness or select upper case output for this call.
+func New(opts ...NewOption) (string, error) {
+	if len(opts) > 0 {
+		return newWithOptions(opts)
+	}
+	return Default().New()
 }
 
-// NewTime returns a new ULID with the given timestamp in milliseconds.
-// Hyper-optimized version that avoids all unnecessary allocations
+// NewTime returns a new ULID with the given timestamp in milliseconds, using
+// the default generator.
 func NewTime(timestamp uint64) (string, error) {
-	if timestamp > maxTimestamp {
-		return "", errors.New("timestamp out of range")
-	}
-
-	randomness, err := generateRandomness()
-	if err != nil {
-		return "", err
-	}
-
-	// Critical section optimized for minimal lock time
-	mutex.Lock()
-	if timestamp == lastTime {
-		// Inline comparison for maximum speed
-		needIncrement := true
-		for i := 0; i < randomnessBytes && needIncrement; i++ {
-			if randomness[i] > lastRandomness[i] {
-				needIncrement = false
-			} else if randomness[i] < lastRandomness[i] {
-				needIncrement = true
-				break
-			}
-		}
-
-		if needIncrement {
-			// Fast copy and increment
-			copy(randomness[:], lastRandomness[:])
-
-			// Unrolled increment for maximum speed
-			randomness[9]++
-			if randomness[9] == 0 {
-				randomness[8]++
-				if randomness[8] == 0 {
-					randomness[7]++
-					if randomness[7] == 0 {
-						randomness[6]++
-						if randomness[6] == 0 {
-							randomness[5]++
-							if randomness[5] == 0 {
-								randomness[4]++
-								if randomness[4] == 0 {
-									randomness[3]++
-									if randomness[3] == 0 {
-										randomness[2]++
-										if randomness[2] == 0 {
-											randomness[1]++
-											if randomness[1] == 0 {
-												randomness[0]++
-												if randomness[0] == 0 {
-													// Overflow - increment timestamp
-													timestamp++
-													if timestamp > maxTimestamp {
-														mutex.Unlock()
-														return "", errors.New("timestamp out of range due to randomness exhaustion")
-													}
-													randomness, err = generateRandomness()
-													if err != nil {
-														mutex.Unlock()
-														return "", err
-													}
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-		}
-	}
-
-	lastTime = timestamp
-	lastRandomness = randomness
-	mutex.Unlock()
+	return Default().NewTime(timestamp)
+}
 
-	// Direct encoding without intermediate ULID struct allocation
-	var data [totalBytes]byte
+// NewULID returns a new ULID as a struct, avoiding the encode/decode round
+// trip when the caller needs the binary form or the timestamp.
+func NewULID() (ULID, error) {
+	return Default().NewULID()
+}
 
-	// Unrolled timestamp encoding
-	data[0] = byte(timestamp >> 40)
-	data[1] = byte(timestamp >> 32)
-	data[2] = byte(timestamp >> 24)
-	data[3] = byte(timestamp >> 16)
-	data[4] = byte(timestamp >> 8)
-	data[5] = byte(timestamp)
+// NewULIDTime returns a new ULID struct with the given timestamp in milliseconds.
+func NewULIDTime(timestamp uint64) (ULID, error) {
+	return Default().NewULIDTime(timestamp)
+}
 
-	// Copy randomness
-	copy(data[6:], randomness[:])
+// Refresh returns a ULID with the same timestamp as u and fresh randomness
+// drawn from the entropy source of the package-level generator, for retrying
+// an insert that collided while keeping the original creation time. The
+// result bypasses the monotonic generator state.
+func (u ULID) Refresh() (ULID, error) {
+	return Default().Refresh(u)
+}
 
-	return ultraFastEncode(data), nil
+// Refresh is like ULID.Refresh but draws the randomness through g, honoring
+// its entropy source, fallback and node ID. A sub-millisecond fraction stored
+// by WithSubMillisecondPrecision is kept.
+func (g *Generator) Refresh(u ULID) (ULID, error) {
+	randomness, err := g.randomness(u.timestamp, false)
+	if err != nil {
+		return ULID{}, err
+	}
+	if g.subMillisecond {
+		setFraction(&randomness, fraction(&u.randomness))
+	}
+	return ULID{timestamp: u.timestamp, randomness: randomness}, nil
 }
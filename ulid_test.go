@@ -1,6 +1,8 @@
 package ulid
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 	"time"
 )
@@ -89,18 +91,81 @@ func TestTimestampOverflow(t *testing.T) {
 	}
 }
 
+func TestNewULID(t *testing.T) {
+	u, err := NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	parsed, err := Parse(u.String())
+	if err != nil {
+		t.Fatalf("Error parsing ULID: %v", err)
+	}
+	if parsed != u {
+		t.Errorf("Round trip failed: got %s, expected %s", parsed, u)
+	}
+
+	timestamp := uint64(time.Now().UnixMilli())
+	u, err = NewULIDTime(timestamp)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	if u.GetTime() != timestamp {
+		t.Errorf("Timestamp mismatch: got %d, expected %d", u.GetTime(), timestamp)
+	}
+
+	if _, err := NewULIDTime(maxTimestamp + 1); err == nil {
+		t.Errorf("Expected error for timestamp overflow")
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	u, err := NewULID()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	refreshed, err := u.Refresh()
+	if err != nil {
+		t.Fatalf("Error refreshing ULID: %v", err)
+	}
+	if refreshed.GetTime() != u.GetTime() {
+		t.Errorf("Timestamp mismatch: got %d, expected %d", refreshed.GetTime(), u.GetTime())
+	}
+	if refreshed == u {
+		t.Errorf("Expected fresh randomness")
+	}
+}
+
+func TestGeneratorRefreshUsesEntropySource(t *testing.T) {
+	g := NewGenerator(WithEntropy(bytes.NewReader(bytes.Repeat([]byte{0xab}, randomnessBytes))))
+	u, err := ParseStrict("01arz3ndektsv4rrffq69g5far")
+	if err != nil {
+		t.Fatalf("Error parsing ULID: %v", err)
+	}
+
+	refreshed, err := g.Refresh(u)
+	if err != nil {
+		t.Fatalf("Error refreshing ULID: %v", err)
+	}
+	if want := [randomnessBytes]byte(bytes.Repeat([]byte{0xab}, randomnessBytes)); refreshed.Entropy() != want {
+		t.Errorf("Expected randomness from the configured source, got %x", refreshed.Entropy())
+	}
+	if refreshed.GetTime() != u.GetTime() {
+		t.Errorf("Timestamp mismatch: got %d, expected %d", refreshed.GetTime(), u.GetTime())
+	}
+}
+
 func TestRandomnessOverflow(t *testing.T) {
-	mutex.Lock()
-	lastTime = maxTimestamp // Set lastTime to max timestamp
-	// Set lastRandomness to maximum value (all 0xFF)
-	for i := range lastRandomness {
-		lastRandomness[i] = 0xFF
+	g := NewGenerator()
+	// Set the state to the maximum timestamp and randomness (all 0xFF)
+	state := &generatorState{lastTime: maxTimestamp, burst: 1}
+	for i := range state.lastRandomness {
+		state.lastRandomness[i] = 0xFF
 	}
-	mutex.Unlock()
+	g.state.Store(state)
 
-	_, err := NewTime(maxTimestamp) // Call NewTime with max timestamp
-	if err == nil {
-		t.Errorf("Expected error for randomness overflow")
+	_, err := g.NewTime(maxTimestamp) // Call NewTime with max timestamp
+	if !errors.Is(err, ErrEntropyExhausted) {
+		t.Errorf("Expected ErrEntropyExhausted for randomness overflow, got %v", err)
 	}
 }
 
@@ -111,6 +176,12 @@ func BenchmarkNew(b *testing.B) {
 	}
 }
 
+func BenchmarkNewULID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = NewULID()
+	}
+}
+
 func BenchmarkParse(b *testing.B) {
 	ulidStr, _ := New()
 	b.ResetTimer()
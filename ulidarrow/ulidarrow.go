@@ -0,0 +1,104 @@
+// Package ulidarrow converts ULIDs to and from Apache Arrow FixedSizeBinary(16)
+// arrays and Parquet FIXED_LEN_BYTE_ARRAY(16) columns in bulk, so analytics
+// exporters can write ULID-keyed datasets without per-row string conversions.
+// It is a separate module so that the ulid package itself stays free of
+// third-party dependencies.
+//
+// Values are stored in the 16-byte big-endian binary form, so they sort the
+// same way as the IDs themselves.
+package ulidarrow
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/schema"
+	"github.com/cloudresty/ulid"
+)
+
+// DataType is the Arrow type of a ULID column, FixedSizeBinary(16).
+var DataType = &arrow.FixedSizeBinaryType{ByteWidth: ulid.BinarySize}
+
+// NewArray returns a FixedSizeBinary(16) array holding ids, with no nulls. The
+// values buffer is encoded in a single pass and handed to Arrow without
+// copying. The caller must Release the array.
+func NewArray(ids []ulid.ULID) *array.FixedSizeBinary {
+	buf := ulid.AppendFixedSizeBinary(make([]byte, 0, len(ids)*ulid.BinarySize), ids)
+	data := array.NewData(DataType, len(ids), []*memory.Buffer{nil, memory.NewBufferBytes(buf)}, nil, 0, 0)
+	defer data.Release()
+	return array.NewFixedSizeBinaryData(data)
+}
+
+// AppendToBuilder appends ids to b, which must have been created with
+// DataType or another FixedSizeBinary(16) type. It is for columns that also
+// hold nulls or are assembled from several batches.
+func AppendToBuilder(b *array.FixedSizeBinaryBuilder, ids []ulid.ULID) {
+	buf := ulid.AppendFixedSizeBinary(make([]byte, 0, len(ids)*ulid.BinarySize), ids)
+	values := make([][]byte, len(ids))
+	for i := range values {
+		values[i] = buf[i*ulid.BinarySize : (i+1)*ulid.BinarySize]
+	}
+	b.AppendValues(values, nil)
+}
+
+// FromArray decodes a FixedSizeBinary(16) array, appending the IDs to dst.
+// Null entries decode to the zero ULID; use arr.IsNull to tell them apart.
+func FromArray(dst []ulid.ULID, arr *array.FixedSizeBinary) ([]ulid.ULID, error) {
+	dtype, ok := arr.DataType().(*arrow.FixedSizeBinaryType)
+	if !ok || dtype.ByteWidth != ulid.BinarySize {
+		return dst, fmt.Errorf("cannot decode %s as ULIDs", arr.DataType())
+	}
+	if arr.Len() == 0 {
+		return dst, nil
+	}
+
+	data := arr.Data()
+	offset := data.Offset() * ulid.BinarySize
+	values := data.Buffers()[1].Bytes()[offset : offset+arr.Len()*ulid.BinarySize]
+	start := len(dst)
+	dst, err := ulid.DecodeFixedSizeBinary(dst, values)
+	if err != nil {
+		return dst, err
+	}
+	if arr.NullN() > 0 {
+		for i := range arr.Len() {
+			if arr.IsNull(i) {
+				dst[start+i] = ulid.ULID{}
+			}
+		}
+	}
+	return dst, nil
+}
+
+// ParquetNode returns a FIXED_LEN_BYTE_ARRAY(16) schema node for a ULID
+// column named name.
+func ParquetNode(name string, repetition parquet.Repetition) (*schema.PrimitiveNode, error) {
+	return schema.NewPrimitiveNode(name, repetition, parquet.Types.FixedLenByteArray, -1, ulid.BinarySize)
+}
+
+// ParquetValues appends the values of ids for a FIXED_LEN_BYTE_ARRAY(16)
+// column writer to dst. The values share a single contiguous buffer.
+func ParquetValues(dst []parquet.FixedLenByteArray, ids []ulid.ULID) []parquet.FixedLenByteArray {
+	buf := ulid.AppendFixedSizeBinary(make([]byte, 0, len(ids)*ulid.BinarySize), ids)
+	for i := range ids {
+		dst = append(dst, buf[i*ulid.BinarySize:(i+1)*ulid.BinarySize])
+	}
+	return dst
+}
+
+// FromParquetValues decodes values read from a FIXED_LEN_BYTE_ARRAY(16)
+// column, appending the IDs to dst. It returns an error if a value is not 16
+// bytes long.
+func FromParquetValues(dst []ulid.ULID, values []parquet.FixedLenByteArray) ([]ulid.ULID, error) {
+	for _, v := range values {
+		id, err := ulid.FromBytes(v)
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, id)
+	}
+	return dst, nil
+}
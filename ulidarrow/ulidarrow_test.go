@@ -0,0 +1,165 @@
+package ulidarrow
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/schema"
+	"github.com/cloudresty/ulid"
+)
+
+func generate(t *testing.T, n int) []ulid.ULID {
+	t.Helper()
+	ids, err := ulid.GenerateBetween(time.Now().Add(-time.Hour), time.Now(), n)
+	if err != nil {
+		t.Fatalf("Error generating ULIDs: %v", err)
+	}
+	return ids
+}
+
+func TestArrayRoundTrip(t *testing.T) {
+	ids := generate(t, 100)
+
+	arr := NewArray(ids)
+	defer arr.Release()
+	if arr.Len() != len(ids) || !arrow.TypeEqual(arr.DataType(), DataType) {
+		t.Fatalf("Array mismatch: got %d values of %s", arr.Len(), arr.DataType())
+	}
+	if want := ids[0].Bytes(); !bytes.Equal(arr.Value(0), want[:]) {
+		t.Errorf("First value mismatch: got %x, expected %x", arr.Value(0), want)
+	}
+
+	decoded, err := FromArray(nil, arr)
+	if err != nil {
+		t.Fatalf("Error decoding array: %v", err)
+	}
+	for i := range ids {
+		if decoded[i] != ids[i] {
+			t.Fatalf("Value %d mismatch: got %s, expected %s", i, decoded[i], ids[i])
+		}
+	}
+
+	slice := array.NewSlice(arr, 10, 20).(*array.FixedSizeBinary)
+	defer slice.Release()
+	decoded, err = FromArray(nil, slice)
+	if err != nil {
+		t.Fatalf("Error decoding slice: %v", err)
+	}
+	if len(decoded) != 10 || decoded[0] != ids[10] || decoded[9] != ids[19] {
+		t.Errorf("Slice mismatch: got %v", decoded)
+	}
+}
+
+func TestBuilderWithNulls(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ids := generate(t, 3)
+	b := array.NewFixedSizeBinaryBuilder(mem, DataType)
+	defer b.Release()
+	AppendToBuilder(b, ids[:2])
+	b.AppendNull()
+	AppendToBuilder(b, ids[2:])
+
+	arr := b.NewFixedSizeBinaryArray()
+	defer arr.Release()
+	decoded, err := FromArray(nil, arr)
+	if err != nil {
+		t.Fatalf("Error decoding array: %v", err)
+	}
+	want := []ulid.ULID{ids[0], ids[1], {}, ids[2]}
+	if len(decoded) != len(want) {
+		t.Fatalf("Length mismatch: got %d, expected %d", len(decoded), len(want))
+	}
+	for i := range want {
+		if decoded[i] != want[i] {
+			t.Errorf("Value %d mismatch: got %s, expected %s", i, decoded[i], want[i])
+		}
+	}
+	if !arr.IsNull(2) {
+		t.Errorf("Expected value 2 to be null")
+	}
+}
+
+func TestFromArrayWrongWidth(t *testing.T) {
+	b := array.NewFixedSizeBinaryBuilder(memory.NewGoAllocator(), &arrow.FixedSizeBinaryType{ByteWidth: 8})
+	defer b.Release()
+	b.Append(make([]byte, 8))
+	arr := b.NewFixedSizeBinaryArray()
+	defer arr.Release()
+
+	if _, err := FromArray(nil, arr); err == nil {
+		t.Errorf("Expected error for a FixedSizeBinary(8) array")
+	}
+}
+
+func TestParquetRoundTrip(t *testing.T) {
+	ids := generate(t, 100)
+
+	node, err := ParquetNode("id", parquet.Repetitions.Required)
+	if err != nil {
+		t.Fatalf("Error creating schema node: %v", err)
+	}
+	root, err := schema.NewGroupNode("schema", parquet.Repetitions.Required, schema.FieldList{node}, -1)
+	if err != nil {
+		t.Fatalf("Error creating schema: %v", err)
+	}
+
+	var buf bytes.Buffer
+	w := file.NewParquetWriter(&buf, root)
+	rg := w.AppendRowGroup()
+	col, err := rg.NextColumn()
+	if err != nil {
+		t.Fatalf("Error opening column: %v", err)
+	}
+	if _, err := col.(*file.FixedLenByteArrayColumnChunkWriter).WriteBatch(ParquetValues(nil, ids), nil, nil); err != nil {
+		t.Fatalf("Error writing column: %v", err)
+	}
+	if err := col.Close(); err != nil {
+		t.Fatalf("Error closing column: %v", err)
+	}
+	if err := rg.Close(); err != nil {
+		t.Fatalf("Error closing row group: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Error closing writer: %v", err)
+	}
+
+	r, err := file.NewParquetReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Error opening file: %v", err)
+	}
+	defer r.Close()
+	reader, err := r.RowGroup(0).Column(0)
+	if err != nil {
+		t.Fatalf("Error opening column: %v", err)
+	}
+	values := make([]parquet.FixedLenByteArray, len(ids))
+	_, n, err := reader.(*file.FixedLenByteArrayColumnChunkReader).ReadBatch(int64(len(ids)), values, nil, nil)
+	if err != nil {
+		t.Fatalf("Error reading column: %v", err)
+	}
+
+	decoded, err := FromParquetValues(nil, values[:n])
+	if err != nil {
+		t.Fatalf("Error decoding column: %v", err)
+	}
+	if len(decoded) != len(ids) {
+		t.Fatalf("Length mismatch: got %d, expected %d", len(decoded), len(ids))
+	}
+	for i := range ids {
+		if decoded[i] != ids[i] {
+			t.Fatalf("Value %d mismatch: got %s, expected %s", i, decoded[i], ids[i])
+		}
+	}
+
+	if _, err := FromParquetValues(nil, []parquet.FixedLenByteArray{make([]byte, 4)}); err == nil {
+		t.Errorf("Expected error for a 4-byte value")
+	}
+}
@@ -0,0 +1,44 @@
+// Package ulidecho installs the ULID request-ID middleware of ulidhttp in
+// Echo. It is a separate module so that the ulid package itself stays free of
+// third-party dependencies.
+package ulidecho
+
+import (
+	"net/http"
+
+	"github.com/cloudresty/ulid"
+	"github.com/cloudresty/ulid/ulidhttp"
+	"github.com/labstack/echo/v4"
+)
+
+// ContextKey is the Echo context key under which Middleware stores the
+// request ID.
+const ContextKey = "ulid.request_id"
+
+// Middleware assigns a request ID to every request, as ulidhttp.Middleware
+// does: a valid ULID in the incoming X-Request-ID header is reused, otherwise
+// a new one is generated. The ID is echoed in the response header and stored
+// both in the Echo context, for FromContext, and in the request's context,
+// for ulid.FromContext.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			id, err := ulidhttp.RequestID(req.Header.Get(ulidhttp.HeaderName))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+			}
+
+			c.Response().Header().Set(ulidhttp.HeaderName, id.String())
+			c.Set(ContextKey, id)
+			c.SetRequest(req.WithContext(ulid.ContextWith(req.Context(), id)))
+			return next(c)
+		}
+	}
+}
+
+// FromContext returns the request ID stored by Middleware, if any.
+func FromContext(c echo.Context) (ulid.ULID, bool) {
+	id, ok := c.Get(ContextKey).(ulid.ULID)
+	return id, ok
+}
@@ -0,0 +1,60 @@
+package ulidecho
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudresty/ulid"
+	"github.com/cloudresty/ulid/ulidhttp"
+	"github.com/labstack/echo/v4"
+)
+
+func TestMiddleware(t *testing.T) {
+	var fromEcho, fromRequest ulid.ULID
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/", func(c echo.Context) error {
+		var ok bool
+		if fromEcho, ok = FromContext(c); !ok {
+			t.Error("Expected request ID in the Echo context")
+		}
+		if fromRequest, ok = ulid.FromContext(c.Request().Context()); !ok {
+			t.Error("Expected request ID in the request context")
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if fromEcho.IsZero() || fromEcho != fromRequest {
+		t.Fatalf("Request ID mismatch: echo %s, request %s", fromEcho, fromRequest)
+	}
+	if got := rec.Header().Get(ulidhttp.HeaderName); got != fromEcho.String() {
+		t.Errorf("Response header mismatch: got %s, expected %s", got, fromEcho)
+	}
+}
+
+func TestMiddlewareReusesIncomingID(t *testing.T) {
+	incoming, err := ulid.New()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+
+	var seen ulid.ULID
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/", func(c echo.Context) error {
+		seen, _ = FromContext(c)
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(ulidhttp.HeaderName, incoming)
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	if seen.String() != incoming {
+		t.Errorf("Expected incoming ID %s to be reused, got %s", incoming, seen)
+	}
+}
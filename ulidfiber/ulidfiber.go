@@ -0,0 +1,39 @@
+// Package ulidfiber provides the ULID request-ID middleware of ulidhttp for
+// Fiber. It is a separate module so that the ulid package itself stays free
+// of third-party dependencies.
+package ulidfiber
+
+import (
+	"github.com/cloudresty/ulid"
+	"github.com/cloudresty/ulid/ulidhttp"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocalsKey is the Fiber locals key under which Middleware stores the
+// request ID.
+const LocalsKey = "ulid.request_id"
+
+// Middleware assigns a request ID to every request, as ulidhttp.Middleware
+// does: a valid ULID in the incoming X-Request-ID header is reused, otherwise
+// a new one is generated. The ID is echoed in the response header and stored
+// both in the request locals, for FromContext, and in the user context, for
+// ulid.FromContext(c.UserContext()).
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := ulidhttp.RequestID(c.Get(ulidhttp.HeaderName))
+		if err != nil {
+			return err
+		}
+
+		c.Set(ulidhttp.HeaderName, id.String())
+		c.Locals(LocalsKey, id)
+		c.SetUserContext(ulid.ContextWith(c.UserContext(), id))
+		return c.Next()
+	}
+}
+
+// FromContext returns the request ID stored by Middleware, if any.
+func FromContext(c *fiber.Ctx) (ulid.ULID, bool) {
+	id, ok := c.Locals(LocalsKey).(ulid.ULID)
+	return id, ok
+}
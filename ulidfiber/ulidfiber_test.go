@@ -0,0 +1,63 @@
+package ulidfiber
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudresty/ulid"
+	"github.com/cloudresty/ulid/ulidhttp"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMiddleware(t *testing.T) {
+	var fromLocals, fromUser ulid.ULID
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/", func(c *fiber.Ctx) error {
+		var ok bool
+		if fromLocals, ok = FromContext(c); !ok {
+			t.Error("Expected request ID in the locals")
+		}
+		if fromUser, ok = ulid.FromContext(c.UserContext()); !ok {
+			t.Error("Expected request ID in the user context")
+		}
+		return nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatalf("Error serving request: %v", err)
+	}
+
+	if fromLocals.IsZero() || fromLocals != fromUser {
+		t.Fatalf("Request ID mismatch: locals %s, user context %s", fromLocals, fromUser)
+	}
+	if got := resp.Header.Get(ulidhttp.HeaderName); got != fromLocals.String() {
+		t.Errorf("Response header mismatch: got %s, expected %s", got, fromLocals)
+	}
+}
+
+func TestMiddlewareReusesIncomingID(t *testing.T) {
+	incoming, err := ulid.New()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+
+	var seen ulid.ULID
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/", func(c *fiber.Ctx) error {
+		seen, _ = FromContext(c)
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(ulidhttp.HeaderName, incoming)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Error serving request: %v", err)
+	}
+
+	if seen.String() != incoming {
+		t.Errorf("Expected incoming ID %s to be reused, got %s", incoming, seen)
+	}
+}
@@ -0,0 +1,46 @@
+// Package ulidgin installs the ULID request-ID middleware of ulidhttp in gin.
+// It is a separate module so that the ulid package itself stays free of
+// third-party dependencies.
+package ulidgin
+
+import (
+	"net/http"
+
+	"github.com/cloudresty/ulid"
+	"github.com/cloudresty/ulid/ulidhttp"
+	"github.com/gin-gonic/gin"
+)
+
+// ContextKey is the gin context key under which Middleware stores the
+// request ID.
+const ContextKey = "ulid.request_id"
+
+// Middleware assigns a request ID to every request, as ulidhttp.Middleware
+// does: a valid ULID in the incoming X-Request-ID header is reused, otherwise
+// a new one is generated. The ID is echoed in the response header and stored
+// both in the gin context, for FromContext, and in the request's context, for
+// ulid.FromContext.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := ulidhttp.RequestID(c.GetHeader(ulidhttp.HeaderName))
+		if err != nil {
+			_ = c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+
+		c.Header(ulidhttp.HeaderName, id.String())
+		c.Set(ContextKey, id)
+		c.Request = c.Request.WithContext(ulid.ContextWith(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// FromContext returns the request ID stored by Middleware, if any.
+func FromContext(c *gin.Context) (ulid.ULID, bool) {
+	v, ok := c.Get(ContextKey)
+	if !ok {
+		return ulid.ULID{}, false
+	}
+	id, ok := v.(ulid.ULID)
+	return id, ok
+}
@@ -0,0 +1,62 @@
+package ulidgin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudresty/ulid"
+	"github.com/cloudresty/ulid/ulidhttp"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestMiddleware(t *testing.T) {
+	var fromGin, fromRequest ulid.ULID
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/", func(c *gin.Context) {
+		var ok bool
+		if fromGin, ok = FromContext(c); !ok {
+			t.Error("Expected request ID in the gin context")
+		}
+		if fromRequest, ok = ulid.FromContext(c.Request.Context()); !ok {
+			t.Error("Expected request ID in the request context")
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if fromGin.IsZero() || fromGin != fromRequest {
+		t.Fatalf("Request ID mismatch: gin %s, request %s", fromGin, fromRequest)
+	}
+	if got := rec.Header().Get(ulidhttp.HeaderName); got != fromGin.String() {
+		t.Errorf("Response header mismatch: got %s, expected %s", got, fromGin)
+	}
+}
+
+func TestMiddlewareReusesIncomingID(t *testing.T) {
+	incoming, err := ulid.New()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+
+	var seen ulid.ULID
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/", func(c *gin.Context) {
+		seen, _ = FromContext(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(ulidhttp.HeaderName, incoming)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if seen.String() != incoming {
+		t.Errorf("Expected incoming ID %s to be reused, got %s", incoming, seen)
+	}
+}
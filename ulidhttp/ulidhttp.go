@@ -0,0 +1,45 @@
+// Package ulidhttp provides net/http middleware that assigns a ULID request ID
+// to every request and makes it available through ulid.FromContext.
+//
+// Middleware has the standard func(http.Handler) http.Handler signature, so it
+// can be installed directly in routers built on net/http such as chi:
+//
+//	r := chi.NewRouter()
+//	r.Use(ulidhttp.Middleware)
+package ulidhttp
+
+import (
+	"net/http"
+
+	"github.com/cloudresty/ulid"
+)
+
+// HeaderName is the header used to receive and return the request ID.
+const HeaderName = "X-Request-ID"
+
+// RequestID returns the ULID carried by an incoming request ID header value,
+// or a newly generated ULID when the value is empty or not a valid ULID.
+func RequestID(header string) (ulid.ULID, error) {
+	if header != "" {
+		if u, err := ulid.Parse(header); err == nil {
+			return u, nil
+		}
+	}
+	return ulid.NewULID()
+}
+
+// Middleware assigns a request ID to every request. A valid ULID in the
+// incoming X-Request-ID header is reused; otherwise a new one is generated.
+// The ID is stored in the request context and echoed in the response header.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := RequestID(r.Header.Get(HeaderName))
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set(HeaderName, id.String())
+		next.ServeHTTP(w, r.WithContext(ulid.ContextWith(r.Context(), id)))
+	})
+}
@@ -0,0 +1,87 @@
+package ulidhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudresty/ulid"
+)
+
+func TestMiddleware(t *testing.T) {
+	var seen ulid.ULID
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := ulid.FromContext(r.Context())
+		if !ok {
+			t.Errorf("Expected request ID in context")
+		}
+		seen = id
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if seen.IsZero() {
+		t.Fatalf("Expected a generated request ID")
+	}
+	if got := rec.Header().Get(HeaderName); got != seen.String() {
+		t.Errorf("Response header mismatch: got %s, expected %s", got, seen.String())
+	}
+}
+
+func TestMiddlewareReusesIncomingID(t *testing.T) {
+	incoming, err := ulid.New()
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+
+	var seen ulid.ULID
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = ulid.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderName, incoming)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if seen.String() != incoming {
+		t.Errorf("Expected incoming ID %s to be reused, got %s", incoming, seen.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderName, "not-a-ulid")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if seen.IsZero() || seen.String() == incoming {
+		t.Errorf("Expected a fresh ID for an invalid incoming header, got %s", seen.String())
+	}
+}
+
+// failingReader is an entropy source that always fails
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("entropy unavailable")
+}
+
+func TestMiddlewareGenerationError(t *testing.T) {
+	previous := ulid.Default()
+	defer ulid.SetDefault(previous)
+	ulid.SetDefault(ulid.NewGenerator(ulid.WithEntropy(failingReader{})))
+
+	if _, err := RequestID(""); err == nil {
+		t.Fatalf("Expected the generation error from RequestID")
+	}
+
+	called := false
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if called || rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected a 500 without calling the handler, got %d (called %v)", rec.Code, called)
+	}
+}
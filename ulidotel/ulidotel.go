@@ -0,0 +1,73 @@
+// Package ulidotel reports ULID generation events as OpenTelemetry metrics.
+// It is a separate module so that the ulid package itself stays free of
+// third-party dependencies.
+package ulidotel
+
+import (
+	"context"
+
+	"github.com/cloudresty/ulid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// instrumentationName identifies the meter used by Hooks
+const instrumentationName = "github.com/cloudresty/ulid/ulidotel"
+
+// Hooks implements ulid.Hooks by recording OpenTelemetry counters: generated
+// IDs, overflows labelled with the overflow policy, and entropy errors.
+type Hooks struct {
+	generated      metric.Int64Counter
+	overflows      metric.Int64Counter
+	entropyErrors  metric.Int64Counter
+	policyAttrSets [4]metric.AddOption
+}
+
+// NewHooks creates the instruments on a meter obtained from provider. Install
+// the result with ulid.WithHooks.
+func NewHooks(provider metric.MeterProvider) (*Hooks, error) {
+	meter := provider.Meter(instrumentationName)
+
+	generated, err := meter.Int64Counter("ulid.generated",
+		metric.WithDescription("Number of ULIDs generated."))
+	if err != nil {
+		return nil, err
+	}
+	overflows, err := meter.Int64Counter("ulid.overflows",
+		metric.WithDescription("Randomness overflows within a millisecond."))
+	if err != nil {
+		return nil, err
+	}
+	entropyErrors, err := meter.Int64Counter("ulid.entropy_errors",
+		metric.WithDescription("Failed reads from the entropy source."))
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Hooks{generated: generated, overflows: overflows, entropyErrors: entropyErrors}
+	for i := range h.policyAttrSets {
+		policy := ulid.OverflowPolicy(i)
+		h.policyAttrSets[i] = metric.WithAttributes(attribute.String("policy", policy.String()))
+	}
+	return h, nil
+}
+
+// OnGenerate implements ulid.Hooks.
+func (h *Hooks) OnGenerate(ulid.ULID) {
+	h.generated.Add(context.Background(), 1)
+}
+
+// OnOverflow implements ulid.Hooks.
+func (h *Hooks) OnOverflow(_ uint64, policy ulid.OverflowPolicy) {
+	if int(policy) >= 0 && int(policy) < len(h.policyAttrSets) {
+		h.overflows.Add(context.Background(), 1, h.policyAttrSets[policy])
+		return
+	}
+	h.overflows.Add(context.Background(), 1,
+		metric.WithAttributes(attribute.String("policy", policy.String())))
+}
+
+// OnEntropyError implements ulid.Hooks.
+func (h *Hooks) OnEntropyError(error) {
+	h.entropyErrors.Add(context.Background(), 1)
+}
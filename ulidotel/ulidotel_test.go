@@ -0,0 +1,91 @@
+package ulidotel
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cloudresty/ulid"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// collect returns the sums recorded by reader, keyed by instrument name
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Sum[int64] {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Error collecting metrics: %v", err)
+	}
+
+	sums := make(map[string]metricdata.Sum[int64])
+	for _, sm := range rm.ScopeMetrics {
+		if sm.Scope.Name != instrumentationName {
+			t.Errorf("Unexpected instrumentation scope %q", sm.Scope.Name)
+		}
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				t.Fatalf("Expected %s to be an int64 sum, got %T", m.Name, m.Data)
+			}
+			sums[m.Name] = sum
+		}
+	}
+	return sums
+}
+
+func TestHooks(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	hooks, err := NewHooks(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatalf("Error creating hooks: %v", err)
+	}
+
+	g := ulid.NewGenerator(ulid.WithHooks(hooks))
+	for range 3 {
+		if _, err := g.New(); err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+	}
+	hooks.OnOverflow(0, ulid.OverflowError)
+
+	failing := ulid.NewGenerator(ulid.WithHooks(hooks), ulid.WithEntropy(bytes.NewReader(nil)))
+	if _, err := failing.New(); err == nil {
+		t.Fatal("Expected an error from an exhausted entropy source")
+	}
+
+	sums := collect(t, reader)
+	if got := sums["ulid.generated"].DataPoints; len(got) != 1 || got[0].Value != 3 {
+		t.Errorf("Expected 3 generated IDs, got %+v", got)
+	}
+	if got := sums["ulid.entropy_errors"].DataPoints; len(got) != 1 || got[0].Value != 1 {
+		t.Errorf("Expected 1 entropy error, got %+v", got)
+	}
+
+	overflows := sums["ulid.overflows"].DataPoints
+	if len(overflows) != 1 || overflows[0].Value != 1 {
+		t.Fatalf("Expected 1 overflow, got %+v", overflows)
+	}
+	if policy, _ := overflows[0].Attributes.Value(attribute.Key("policy")); policy.AsString() != "error" {
+		t.Errorf("Expected the policy attribute \"error\", got %q", policy.AsString())
+	}
+}
+
+func TestHooksUnknownPolicy(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	hooks, err := NewHooks(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatalf("Error creating hooks: %v", err)
+	}
+
+	hooks.OnOverflow(0, ulid.OverflowPolicy(42))
+
+	overflows := collect(t, reader)["ulid.overflows"].DataPoints
+	if len(overflows) != 1 {
+		t.Fatalf("Expected 1 overflow, got %+v", overflows)
+	}
+	if policy, _ := overflows[0].Attributes.Value("policy"); policy.AsString() != "OverflowPolicy(42)" {
+		t.Errorf("Unexpected policy attribute %q", policy.AsString())
+	}
+}
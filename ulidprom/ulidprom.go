@@ -0,0 +1,89 @@
+// Package ulidprom exports ULID generator statistics to Prometheus. It is a
+// separate module so that the ulid package itself stays free of third-party
+// dependencies.
+package ulidprom
+
+import (
+	"github.com/cloudresty/ulid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a prometheus.Collector reporting the Stats of a ULID
+// generator. Rates, such as IDs generated per second, are derived from the
+// counters with PromQL's rate().
+type Collector struct {
+	stats func() ulid.GeneratorStats
+
+	generated                *prometheus.Desc
+	sameMillisecondSequences *prometheus.Desc
+	maxBurst                 *prometheus.Desc
+	monotonicBumps           *prometheus.Desc
+	overflows                *prometheus.Desc
+	entropyReads             *prometheus.Desc
+	entropyFailures          *prometheus.Desc
+	fallbackReads            *prometheus.Desc
+	clockRegressions         *prometheus.Desc
+	clockSkew                *prometheus.Desc
+	lockWait                 *prometheus.Desc
+}
+
+// NewCollector returns a Collector reading stats from the given function,
+// typically ulid.Stats for the package-level generator or the Stats method of
+// a *ulid.Generator. Metric names are prefixed with namespace and "ulid", and
+// constLabels are attached to every metric, e.g. to tell generators apart.
+func NewCollector(stats func() ulid.GeneratorStats, namespace string, constLabels prometheus.Labels) *Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "ulid", name), help, nil, constLabels)
+	}
+
+	return &Collector{
+		stats: stats,
+
+		generated:                desc("generated_total", "Total number of ULIDs generated."),
+		sameMillisecondSequences: desc("same_millisecond_sequences_total", "Milliseconds in which more than one ULID was generated."),
+		maxBurst:                 desc("max_burst", "Largest number of ULIDs generated within a single millisecond."),
+		monotonicBumps:           desc("monotonic_bumps_total", "Times the previous randomness was incremented to preserve ordering."),
+		overflows:                desc("overflows_total", "Randomness overflows within a millisecond."),
+		entropyReads:             desc("entropy_reads_total", "Reads from the entropy source."),
+		entropyFailures:          desc("entropy_failures_total", "Failed reads from the entropy source."),
+		fallbackReads:            desc("entropy_fallback_reads_total", "Reads served by the fallback entropy source."),
+		clockRegressions:         desc("clock_regressions_total", "Times the wall clock was observed moving backwards."),
+		clockSkew:                desc("clock_skew_seconds", "How far the latest clock-based ULID timestamp was ahead of the wall clock."),
+		lockWait:                 desc("lock_wait_seconds_total", "Total time spent waiting for the same-millisecond generation lock."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.generated
+	ch <- c.sameMillisecondSequences
+	ch <- c.maxBurst
+	ch <- c.monotonicBumps
+	ch <- c.overflows
+	ch <- c.entropyReads
+	ch <- c.entropyFailures
+	ch <- c.fallbackReads
+	ch <- c.clockRegressions
+	ch <- c.clockSkew
+	ch <- c.lockWait
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stats()
+
+	counter := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value)
+	}
+	counter(c.generated, float64(s.Generated))
+	counter(c.sameMillisecondSequences, float64(s.SameMillisecondSequences))
+	ch <- prometheus.MustNewConstMetric(c.maxBurst, prometheus.GaugeValue, float64(s.MaxBurst))
+	counter(c.monotonicBumps, float64(s.MonotonicBumps))
+	counter(c.overflows, float64(s.Overflows))
+	counter(c.entropyReads, float64(s.EntropyRefills))
+	counter(c.entropyFailures, float64(s.EntropyFailures))
+	counter(c.fallbackReads, float64(s.FallbackReads))
+	counter(c.clockRegressions, float64(s.ClockRegressions))
+	ch <- prometheus.MustNewConstMetric(c.clockSkew, prometheus.GaugeValue, s.ClockSkew.Seconds())
+	counter(c.lockWait, s.LockWait.Seconds())
+}
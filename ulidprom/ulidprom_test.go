@@ -0,0 +1,72 @@
+package ulidprom
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudresty/ulid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	stats := ulid.GeneratorStats{
+		Generated:                42,
+		SameMillisecondSequences: 3,
+		MaxBurst:                 7,
+		ClockSkew:                1500 * time.Millisecond,
+		LockWait:                 250 * time.Millisecond,
+	}
+	collector := NewCollector(func() ulid.GeneratorStats { return stats }, "app", prometheus.Labels{"generator": "orders"})
+
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatalf("Error registering collector: %v", err)
+	}
+
+	expected := `
+# HELP app_ulid_generated_total Total number of ULIDs generated.
+# TYPE app_ulid_generated_total counter
+app_ulid_generated_total{generator="orders"} 42
+# HELP app_ulid_max_burst Largest number of ULIDs generated within a single millisecond.
+# TYPE app_ulid_max_burst gauge
+app_ulid_max_burst{generator="orders"} 7
+# HELP app_ulid_clock_skew_seconds How far the latest clock-based ULID timestamp was ahead of the wall clock.
+# TYPE app_ulid_clock_skew_seconds gauge
+app_ulid_clock_skew_seconds{generator="orders"} 1.5
+# HELP app_ulid_lock_wait_seconds_total Total time spent waiting for the same-millisecond generation lock.
+# TYPE app_ulid_lock_wait_seconds_total counter
+app_ulid_lock_wait_seconds_total{generator="orders"} 0.25
+`
+	err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"app_ulid_generated_total", "app_ulid_max_burst", "app_ulid_clock_skew_seconds", "app_ulid_lock_wait_seconds_total")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if n, err := testutil.GatherAndCount(registry); err != nil || n != 11 {
+		t.Errorf("Expected 11 metrics, got %d, %v", n, err)
+	}
+}
+
+func TestCollectorGenerator(t *testing.T) {
+	g := ulid.NewGenerator()
+	for range 3 {
+		if _, err := g.New(); err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+	}
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(NewCollector(g.Stats, "", nil))
+
+	expected := `
+# HELP ulid_generated_total Total number of ULIDs generated.
+# TYPE ulid_generated_total counter
+ulid_generated_total 3
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "ulid_generated_total"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,247 @@
+// Package ulidsql renders SQL definitions that generate and parse ULIDs inside
+// the database, using the same bit layout and Crockford Base32 alphabet as
+// github.com/cloudresty/ulid, so IDs created by a column default are
+// interchangeable with IDs created in Go.
+//
+// Database-side generation is not monotonic within a millisecond; IDs created
+// in the same millisecond sort randomly relative to each other.
+package ulidsql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudresty/ulid"
+)
+
+// Dialect identifies a SQL database flavour.
+type Dialect string
+
+// Supported dialects.
+const (
+	Postgres Dialect = "postgres"
+	MySQL    Dialect = "mysql"
+	SQLite   Dialect = "sqlite"
+)
+
+// alphabet is the lowercase Crockford Base32 alphabet used by the ulid package
+const alphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// Dialects returns all supported dialects.
+func Dialects() []Dialect {
+	return []Dialect{Postgres, MySQL, SQLite}
+}
+
+// ParseDialect returns the dialect with the given name. "postgresql", "pg"
+// and "sqlite3" are accepted as aliases.
+func ParseDialect(name string) (Dialect, error) {
+	switch strings.ToLower(name) {
+	case "postgres", "postgresql", "pg":
+		return Postgres, nil
+	case "mysql":
+		return MySQL, nil
+	case "sqlite", "sqlite3":
+		return SQLite, nil
+	}
+	return "", fmt.Errorf("unsupported SQL dialect %q", name)
+}
+
+// Functions returns a ready-to-run SQL script for the given dialect.
+//
+// For Postgres (13+) the script defines gen_ulid() and ulid_to_timestamp(text),
+// so columns can be declared with DEFAULT gen_ulid(). For MySQL (8.0+) it
+// defines the same functions using DELIMITER blocks for the mysql client;
+// MySQL does not allow stored functions in column defaults, so assign IDs in a
+// BEFORE INSERT trigger. SQLite has no SQL-defined functions, so the script
+// creates a ulid_new view yielding one fresh ID; use TimestampExpr to decode.
+func Functions(d Dialect) (string, error) {
+	switch d {
+	case Postgres:
+		return strings.ReplaceAll(postgresFunctions, "{{alphabet}}", alphabet), nil
+	case MySQL:
+		return strings.ReplaceAll(mysqlFunctions, "{{alphabet}}", alphabet), nil
+	case SQLite:
+		return strings.ReplaceAll(sqliteFunctions, "{{alphabet}}", alphabet), nil
+	}
+	return "", fmt.Errorf("unsupported SQL dialect %q", d)
+}
+
+// TimestampExpr returns a SQLite expression that decodes the Unix millisecond
+// timestamp embedded in the ULID stored in column. It accepts upper and lower
+// case input but does not validate the ID.
+func TimestampExpr(column string) string {
+	var b strings.Builder
+	b.WriteString("((")
+	for i := 1; i <= 10; i++ {
+		if i > 1 {
+			b.WriteString(" + ")
+		}
+		fmt.Fprintf(&b, "(instr('%s', lower(substr(%s, %d, 1))) - 1) * %d", alphabet, column, i, uint64(1)<<(5*(10-i)))
+	}
+	b.WriteString(") >> 2)")
+	return b.String()
+}
+
+const postgresFunctions = `-- ULID functions compatible with github.com/cloudresty/ulid (Postgres 13+)
+CREATE OR REPLACE FUNCTION gen_ulid() RETURNS text
+LANGUAGE plpgsql VOLATILE AS $$
+DECLARE
+    alphabet CONSTANT text := '{{alphabet}}';
+    ts bigint := floor(extract(epoch FROM clock_timestamp()) * 1000)::bigint;
+    u bytea := uuid_send(gen_random_uuid());
+    -- Skip the UUID version and variant bytes so all 80 bits are random
+    rnd bytea := substring(u FROM 1 FOR 6) || substring(u FROM 11 FOR 4);
+    hi bigint;
+    lo bigint := 0;
+    result text := '';
+BEGIN
+    hi := (ts << 16) | (get_byte(rnd, 0)::bigint << 8) | get_byte(rnd, 1)::bigint;
+    FOR i IN 2..9 LOOP
+        lo := (lo << 8) | get_byte(rnd, i)::bigint;
+    END LOOP;
+
+    FOR i IN 0..25 LOOP
+        result := result || substr(alphabet, 1 + (CASE
+            WHEN i < 12 THEN (hi >> (59 - 5 * i)) & 31
+            WHEN i = 12 THEN ((hi & 15) << 1) | ((lo >> 63) & 1)
+            WHEN i < 25 THEN (lo >> (123 - 5 * i)) & 31
+            ELSE (lo & 7) << 2
+        END)::int, 1);
+    END LOOP;
+
+    RETURN result;
+END
+$$;
+
+CREATE OR REPLACE FUNCTION ulid_to_timestamp(id text) RETURNS timestamptz
+LANGUAGE plpgsql IMMUTABLE STRICT AS $$
+DECLARE
+    alphabet CONSTANT text := '{{alphabet}}';
+    normalized text := translate(lower(id), 'ilou', '110v');
+    acc bigint := 0;
+    v int;
+BEGIN
+    IF length(normalized) <> 26 THEN
+        RAISE EXCEPTION 'invalid ULID length: %', id;
+    END IF;
+
+    FOR i IN 1..26 LOOP
+        v := strpos(alphabet, substr(normalized, i, 1)) - 1;
+        IF v < 0 THEN
+            RAISE EXCEPTION 'invalid character in ULID: %', id;
+        END IF;
+        IF i <= 10 THEN
+            acc := (acc << 5) | v;
+        END IF;
+    END LOOP;
+
+    RETURN 'epoch'::timestamptz + (acc >> 2) * interval '1 millisecond';
+END
+$$;
+`
+
+const mysqlFunctions = `-- ULID functions compatible with github.com/cloudresty/ulid (MySQL 8.0+)
+DROP FUNCTION IF EXISTS gen_ulid;
+DROP FUNCTION IF EXISTS ulid_to_timestamp;
+
+DELIMITER $$
+
+CREATE FUNCTION gen_ulid() RETURNS CHAR(26)
+NOT DETERMINISTIC NO SQL
+BEGIN
+    DECLARE alphabet CHAR(32) DEFAULT '{{alphabet}}';
+    DECLARE ts BIGINT UNSIGNED DEFAULT FLOOR(UNIX_TIMESTAMP(NOW(3)) * 1000);
+    DECLARE rnd BINARY(10) DEFAULT RANDOM_BYTES(10);
+    DECLARE hi BIGINT UNSIGNED;
+    DECLARE lo BIGINT UNSIGNED;
+    DECLARE result VARCHAR(26) DEFAULT '';
+    DECLARE i INT DEFAULT 0;
+
+    SET hi = (ts << 16) | CAST(CONV(HEX(SUBSTRING(rnd, 1, 2)), 16, 10) AS UNSIGNED);
+    SET lo = CAST(CONV(HEX(SUBSTRING(rnd, 3, 8)), 16, 10) AS UNSIGNED);
+
+    WHILE i < 26 DO
+        SET result = CONCAT(result, SUBSTRING(alphabet, 1 + CASE
+            WHEN i < 12 THEN (hi >> (59 - 5 * i)) & 31
+            WHEN i = 12 THEN ((hi & 15) << 1) | (lo >> 63)
+            WHEN i < 25 THEN (lo >> (123 - 5 * i)) & 31
+            ELSE (lo & 7) << 2
+        END, 1));
+        SET i = i + 1;
+    END WHILE;
+
+    RETURN result;
+END$$
+
+CREATE FUNCTION ulid_to_timestamp(id VARCHAR(64)) RETURNS DATETIME(3)
+DETERMINISTIC NO SQL
+BEGIN
+    DECLARE alphabet CHAR(32) DEFAULT '{{alphabet}}';
+    DECLARE normalized VARCHAR(64) DEFAULT LOWER(id);
+    DECLARE acc BIGINT UNSIGNED DEFAULT 0;
+    DECLARE v INT;
+    DECLARE i INT DEFAULT 1;
+
+    SET normalized = REPLACE(REPLACE(REPLACE(REPLACE(normalized, 'i', '1'), 'l', '1'), 'o', '0'), 'u', 'v');
+    IF CHAR_LENGTH(normalized) <> 26 THEN
+        SIGNAL SQLSTATE '22023' SET MESSAGE_TEXT = 'invalid ULID length';
+    END IF;
+
+    WHILE i <= 26 DO
+        SET v = LOCATE(SUBSTRING(normalized, i, 1), alphabet) - 1;
+        IF v < 0 THEN
+            SIGNAL SQLSTATE '22023' SET MESSAGE_TEXT = 'invalid character in ULID';
+        END IF;
+        IF i <= 10 THEN
+            SET acc = (acc << 5) | v;
+        END IF;
+        SET i = i + 1;
+    END WHILE;
+
+    -- FROM_UNIXTIME returns the value in the session time zone
+    RETURN FROM_UNIXTIME((acc >> 2) / 1000);
+END$$
+
+DELIMITER ;
+`
+
+const sqliteFunctions = `-- ULID view compatible with github.com/cloudresty/ulid (SQLite 3.35+)
+-- Usage: INSERT INTO events (id, ...) VALUES ((SELECT id FROM ulid_new), ...);
+CREATE VIEW IF NOT EXISTS ulid_new AS
+WITH RECURSIVE
+    src(h) AS MATERIALIZED (
+        SELECT printf('%012X', CAST((julianday('now') - 2440587.5) * 86400000 AS INTEGER)) || hex(randomblob(10))
+    ),
+    chars(i, out) AS (
+        SELECT 0, ''
+        UNION ALL
+        SELECT i + 1, out || substr('{{alphabet}}', 1 + (((
+            (instr('0123456789ABCDEF', substr(h, 1 + (5 * i) / 4, 1)) - 1) * 16 +
+            (instr('0123456789ABCDEF', substr(h, 2 + (5 * i) / 4, 1)) - 1)
+        ) >> (3 - (5 * i) % 4)) & 31), 1)
+        FROM chars, src
+        WHERE i < 26
+    )
+SELECT out AS id FROM chars WHERE i = 26;
+`
+
+// RangePartitions renders Postgres declarative partitioning statements
+// (also usable with TimescaleDB) attaching one partition of parent per pair of
+// consecutive boundaries, as returned by ulid.PartitionBoundaries. The
+// partition key must be a text column using the "C" collation so that
+// comparisons follow the byte order of the ULID strings.
+func RangePartitions(parent string, boundaries []ulid.ULID) (string, error) {
+	if len(boundaries) < 2 {
+		return "", errors.New("at least two boundaries are required")
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(boundaries); i++ {
+		from, to := boundaries[i-1], boundaries[i]
+		name := parent + "_" + from.Time().Format("20060102_1504")
+		fmt.Fprintf(&b, "CREATE TABLE %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s');\n",
+			name, parent, from.String(), to.String())
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,108 @@
+package ulidsql
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudresty/ulid"
+)
+
+func TestFunctions(t *testing.T) {
+	for _, d := range Dialects() {
+		script, err := Functions(d)
+		if err != nil {
+			t.Fatalf("Error rendering %s functions: %v", d, err)
+		}
+		if !strings.Contains(script, alphabet) {
+			t.Errorf("%s script does not embed the ULID alphabet", d)
+		}
+	}
+
+	if _, err := Functions("oracle"); err == nil {
+		t.Errorf("Expected error for unsupported dialect")
+	}
+}
+
+func TestParseDialect(t *testing.T) {
+	for name, want := range map[string]Dialect{"pg": Postgres, "MySQL": MySQL, "sqlite3": SQLite} {
+		got, err := ParseDialect(name)
+		if err != nil || got != want {
+			t.Errorf("ParseDialect(%q) = %q, %v; expected %q", name, got, err, want)
+		}
+	}
+
+	if _, err := ParseDialect("oracle"); err == nil {
+		t.Errorf("Expected error for unsupported dialect")
+	}
+}
+
+func TestSQLiteMatchesGo(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 binary not available")
+	}
+
+	script, err := Functions(SQLite)
+	if err != nil {
+		t.Fatalf("Error rendering SQLite functions: %v", err)
+	}
+	script += "SELECT id, " + TimestampExpr("id") + " FROM ulid_new;\n"
+
+	cmd := exec.Command("sqlite3", ":memory:")
+	cmd.Stdin = strings.NewReader(script)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Error running sqlite3: %v", err)
+	}
+
+	id, millis, ok := strings.Cut(strings.TrimSpace(string(out)), "|")
+	if !ok {
+		t.Fatalf("Unexpected sqlite3 output: %q", out)
+	}
+
+	parsed, err := ulid.Parse(id)
+	if err != nil {
+		t.Fatalf("Error parsing database ULID %q: %v", id, err)
+	}
+	if parsed.String() != id {
+		t.Errorf("Database ULID is not canonical: got %s, re-encoded %s", id, parsed.String())
+	}
+
+	if strconv.FormatUint(parsed.GetTime(), 10) != millis {
+		t.Errorf("Timestamp mismatch: SQL decoded %s, Go decoded %d", millis, parsed.GetTime())
+	}
+
+	age := time.Since(time.UnixMilli(int64(parsed.GetTime())))
+	if age < -time.Second || age > time.Minute {
+		t.Errorf("Database ULID timestamp is not current: %v old", age)
+	}
+}
+
+func TestRangePartitions(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	boundaries, err := ulid.PartitionBoundaries(start, 24*time.Hour, 2)
+	if err != nil {
+		t.Fatalf("Error computing boundaries: %v", err)
+	}
+
+	sql, err := RangePartitions("events", boundaries)
+	if err != nil {
+		t.Fatalf("Error rendering partitions: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(sql), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 statements, got %d:\n%s", len(lines), sql)
+	}
+	want := "CREATE TABLE events_20240101_0000 PARTITION OF events FOR VALUES FROM ('" +
+		boundaries[0].String() + "') TO ('" + boundaries[1].String() + "');"
+	if lines[0] != want {
+		t.Errorf("Statement mismatch:\ngot  %s\nwant %s", lines[0], want)
+	}
+
+	if _, err := RangePartitions("events", boundaries[:1]); err == nil {
+		t.Errorf("Expected error for a single boundary")
+	}
+}
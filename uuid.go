@@ -0,0 +1,70 @@
+package ulid
+
+import (
+	"encoding/hex"
+	"errors"
+)
+
+// UUIDString returns the ULID's 128 bits in the 8-4-4-4-12 hex UUID form,
+// e.g. for storage in Postgres uuid columns. The bits are not altered, so the
+// result is not a valid RFC 9562 UUID of any particular version.
+func (u ULID) UUIDString() string {
+	return formatUUID(u.bytes())
+}
+
+// FromUUID parses a UUID in the 8-4-4-4-12 hex form, or as 32 hex digits
+// without hyphens, into the ULID with the same 128 bits.
+func FromUUID(s string) (ULID, error) {
+	var digits [32]byte
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return ULID{}, errors.New("invalid UUID format")
+		}
+		n := copy(digits[:], s[0:8])
+		n += copy(digits[n:], s[9:13])
+		n += copy(digits[n:], s[14:18])
+		n += copy(digits[n:], s[19:23])
+		copy(digits[n:], s[24:36])
+	case 32:
+		copy(digits[:], s)
+	default:
+		return ULID{}, errors.New("invalid UUID length")
+	}
+
+	var data [totalBytes]byte
+	if _, err := hex.Decode(data[:], digits[:]); err != nil {
+		return ULID{}, errors.New("invalid UUID format")
+	}
+	return fromBytes(data), nil
+}
+
+// ToUUIDv7 returns the ULID as an RFC 9562 version 7 UUID in the 8-4-4-4-12
+// hex form. Both formats start with a 48-bit Unix millisecond timestamp, which
+// is carried over unchanged; the version and variant fields overwrite 6 of the
+// 80 randomness bits, so the conversion keeps 74 bits of entropy.
+func (u ULID) ToUUIDv7() string {
+	data := u.bytes()
+	data[6] = data[6]&0x0f | 0x70 // version 7
+	data[8] = data[8]&0x3f | 0x80 // variant 10
+	return formatUUID(data)
+}
+
+// FromUUIDv7 parses a version 7 UUID into a ULID with the same timestamp and
+// random bits, clearing the version and variant fields. It returns an error
+// if the UUID is not version 7 with the RFC 9562 variant.
+func FromUUIDv7(s string) (ULID, error) {
+	u, err := FromUUID(s)
+	if err != nil {
+		return ULID{}, err
+	}
+	if u.randomness[0]>>4 != 7 {
+		return ULID{}, errors.New("UUID is not version 7")
+	}
+	if u.randomness[2]>>6 != 0b10 {
+		return ULID{}, errors.New("UUID does not use the RFC 9562 variant")
+	}
+	u.randomness[0] &= 0x0f
+	u.randomness[2] &= 0x3f
+	return u, nil
+}
@@ -0,0 +1,65 @@
+package ulid
+
+import "testing"
+
+func TestUUIDString(t *testing.T) {
+	u := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+
+	s := u.UUIDString()
+	if s != "0186e56d-7000-0102-0304-05060708090a" {
+		t.Errorf("UUIDString mismatch: got %s", s)
+	}
+
+	for _, input := range []string{s, "0186E56D-7000-0102-0304-05060708090A", "0186e56d70000102030405060708090a"} {
+		back, err := FromUUID(input)
+		if err != nil {
+			t.Fatalf("Error parsing UUID %q: %v", input, err)
+		}
+		if back != u {
+			t.Errorf("Round trip failed for %q: got %s, expected %s", input, back, u)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"0186e56d-7000-0102-0304-05060708090",
+		"0186e56d+7000-0102-0304-05060708090a",
+		"0186e56d-7000-0102-0304-05060708090g",
+	}
+	for _, input := range invalid {
+		if _, err := FromUUID(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestUUIDv7(t *testing.T) {
+	u := ULID{timestamp: 1678886400000, randomness: [randomnessBytes]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}}
+
+	s := u.ToUUIDv7()
+	if s != "0186e56d-7000-7fff-bfff-ffffffffffff" {
+		t.Errorf("ToUUIDv7 mismatch: got %s", s)
+	}
+
+	back, err := FromUUIDv7(s)
+	if err != nil {
+		t.Fatalf("Error parsing UUIDv7: %v", err)
+	}
+	if back.GetTime() != u.GetTime() {
+		t.Errorf("Timestamp mismatch: got %d, expected %d", back.GetTime(), u.GetTime())
+	}
+	want := [randomnessBytes]byte{0x0f, 0xff, 0x3f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	if back.Entropy() != want {
+		t.Errorf("Entropy mismatch: got %x, expected %x", back.Entropy(), want)
+	}
+	if back.ToUUIDv7() != s {
+		t.Errorf("Expected stable round trip, got %s", back.ToUUIDv7())
+	}
+
+	if _, err := FromUUIDv7("0186e56d-7000-4fff-bfff-ffffffffffff"); err == nil {
+		t.Errorf("Expected error for version 4 UUID")
+	}
+	if _, err := FromUUIDv7("0186e56d-7000-7fff-cfff-ffffffffffff"); err == nil {
+		t.Errorf("Expected error for wrong variant")
+	}
+}
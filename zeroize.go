@@ -0,0 +1,46 @@
+package ulid
+
+import "crypto/rand"
+
+// WithZeroization reduces what a memory dump of the process reveals about
+// recently issued IDs, for security-sensitive deployments. The Generator
+// stores the randomness of the last ID XOR-masked with a random per-Generator
+// key, and wipes its intermediate randomness buffers once an ID has been
+// encoded. Entropy buffering keeps unused entropy in memory, so combine this
+// option with WithEntropyBuffering(false).
+//
+// Wiping is best effort: copies of an ID's bytes may remain in registers or
+// on goroutine stacks, and the returned ID itself is the caller's to protect.
+func WithZeroization() GeneratorOption {
+	return func(g *Generator) {
+		g.zeroize = true
+		// crypto/rand.Read never returns an error; it crashes the program if
+		// the system source fails
+		_, _ = rand.Read(g.stateMask[:])
+		g.state.Store(&generatorState{lastRandomness: g.stateMask})
+	}
+}
+
+// maskRandomness converts between the raw and the stored form of the last
+// randomness; masking is its own inverse
+func (g *Generator) maskRandomness(randomness [randomnessBytes]byte) [randomnessBytes]byte {
+	for i := range randomness {
+		randomness[i] ^= g.stateMask[i]
+	}
+	return randomness
+}
+
+// encode returns the string form of u in the generator's encoding, wiping u
+// afterwards if zeroization is enabled
+func (g *Generator) encode(u *ULID) string {
+	var s string
+	if g.encoding != nil {
+		s = g.encoding.Encode(*u)
+	} else {
+		s = u.String()
+	}
+	if g.zeroize {
+		clear(u.randomness[:])
+	}
+	return s
+}
@@ -0,0 +1,54 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithZeroization(t *testing.T) {
+	g := NewGenerator(WithZeroization())
+	if g.stateMask == [randomnessBytes]byte{} {
+		t.Fatal("Expected a non-zero state mask")
+	}
+	timestamp := uint64(time.Now().UnixMilli())
+
+	previous := ULID{}
+	for range 100 {
+		u, err := g.NewULIDTime(timestamp)
+		if err != nil {
+			t.Fatalf("Error generating ULID: %v", err)
+		}
+		if u.Compare(previous) <= 0 {
+			t.Fatalf("Not monotonic: %s <= %s", u, previous)
+		}
+		previous = u
+
+		if stored := g.state.Load().lastRandomness; stored == u.randomness {
+			t.Fatal("Expected the last randomness to be stored masked")
+		}
+	}
+
+	s, err := g.NewTime(timestamp)
+	if err != nil {
+		t.Fatalf("Error generating ULID: %v", err)
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Error parsing ULID: %v", err)
+	}
+	if parsed.Compare(previous) <= 0 {
+		t.Errorf("Not monotonic: %s <= %s", parsed, previous)
+	}
+}
+
+func TestGeneratorEncodeWipes(t *testing.T) {
+	u := ULID{timestamp: 1, randomness: [randomnessBytes]byte{1, 2, 3}}
+	want := u.String()
+
+	if got := NewGenerator(WithZeroization()).encode(&u); got != want {
+		t.Errorf("Encoding mismatch: got %s, expected %s", got, want)
+	}
+	if u.randomness != [randomnessBytes]byte{} {
+		t.Error("Expected the randomness to be wiped after encoding")
+	}
+}